@@ -34,6 +34,8 @@ func dataSourceAffinityRule() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -41,7 +43,7 @@ func dataSourceAffinityRule() *schema.Resource {
 func dataSourceAffinityRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if id, ok := d.GetOk("affinity_rule_id"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{id.(string)}))
@@ -26,6 +26,8 @@ func dataSourceAffinityRuleMember() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -33,7 +35,7 @@ func dataSourceAffinityRuleMember() *schema.Resource {
 func dataSourceAffinityRuleMemberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if memberID, ok := d.GetOk("affinity_rule_member_id"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{memberID.(string)}))
@@ -30,6 +30,8 @@ func dataSourceAvailabilityZone() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -37,7 +39,7 @@ func dataSourceAvailabilityZone() *schema.Resource {
 func dataSourceAvailabilityZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if id, ok := d.GetOk("availability_zone_id"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{id.(string)}))
@@ -35,6 +35,8 @@ func dataSourceBackupGateway() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -42,7 +44,7 @@ func dataSourceBackupGateway() *schema.Resource {
 func dataSourceBackupGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if id, ok := d.GetOk("backup_gateway_id"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{id.(string)}))
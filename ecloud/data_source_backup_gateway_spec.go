@@ -27,6 +27,8 @@ func dataSourceBackupGatewaySpecification() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -34,7 +36,7 @@ func dataSourceBackupGatewaySpecification() *schema.Resource {
 func dataSourceBackupGatewaySpecificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if id, ok := d.GetOk("backup_gateway_specification_id"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{id.(string)}))
@@ -1,8 +1,12 @@
 package ecloud
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceAPIRequestFiltersSchema() *schema.Schema {
@@ -16,6 +20,13 @@ func dataSourceAPIRequestFiltersSchema() *schema.Schema {
 					Required: true,
 				},
 
+				"operator": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          connection.EQOperator.String(),
+					ValidateDiagFunc: validateEnum(connection.APIRequestFilteringOperatorEnum),
+				},
+
 				"values": {
 					Type:     schema.TypeList,
 					Required: true,
@@ -28,6 +39,21 @@ func dataSourceAPIRequestFiltersSchema() *schema.Schema {
 	}
 }
 
+// dataSourceAPIRequestSortSchema returns the schema for sorting results, of the form
+// <property> or <property>:<asc|desc>
+func dataSourceAPIRequestSortSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateDiagFunc: validation.ToDiagFunc(func(val interface{}, key string) (warns []string, errs []error) {
+			if _, err := parseDataSourceAPIRequestSorting(val.(string)); err != nil {
+				errs = append(errs, fmt.Errorf("%q %s", key, err))
+			}
+			return
+		}),
+	}
+}
+
 func buildDataSourceAPIRequestFilters(set *schema.Set) []connection.APIRequestFiltering {
 	var filters []connection.APIRequestFiltering
 	for _, v := range set.List() {
@@ -36,7 +62,50 @@ func buildDataSourceAPIRequestFilters(set *schema.Set) []connection.APIRequestFi
 		for _, e := range m["values"].([]interface{}) {
 			filterValues = append(filterValues, e.(string))
 		}
-		filters = append(filters, *connection.NewAPIRequestFiltering(m["property"].(string), connection.EQOperator, filterValues))
+
+		// operator is validated by the schema, falling back to equality for state written prior
+		// to its introduction
+		operator, err := connection.APIRequestFilteringOperatorEnum.Parse(m["operator"].(string))
+		if err != nil {
+			operator = connection.EQOperator
+		}
+
+		filters = append(filters, *connection.NewAPIRequestFiltering(m["property"].(string), operator, filterValues))
 	}
 	return filters
 }
+
+// parseDataSourceAPIRequestSorting parses sorting of the form <property> or <property>:<asc|desc>
+func parseDataSourceAPIRequestSorting(sort string) (connection.APIRequestSorting, error) {
+	property, direction, _ := strings.Cut(sort, ":")
+	if len(property) < 1 {
+		return connection.APIRequestSorting{}, fmt.Errorf("must be of the form <property> or <property>:<asc|desc>, got: %s", sort)
+	}
+
+	switch strings.ToLower(direction) {
+	case "", "asc":
+		return connection.APIRequestSorting{Property: property}, nil
+	case "desc":
+		return connection.APIRequestSorting{Property: property, Descending: true}, nil
+	default:
+		return connection.APIRequestSorting{}, fmt.Errorf("must be of the form <property> or <property>:<asc|desc>, got: %s", sort)
+	}
+}
+
+// dataSourceAPIRequestParameters returns request parameters populated from the filter and sort
+// arguments of a data source, to which the data source adds its own filters
+func dataSourceAPIRequestParameters(d *schema.ResourceData) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+
+	if filters, ok := d.GetOk("filter"); ok {
+		params.WithFilter(buildDataSourceAPIRequestFilters(filters.(*schema.Set))...)
+	}
+
+	if sort, ok := d.GetOk("sort"); ok {
+		// sort is validated by the schema
+		sorting, _ := parseDataSourceAPIRequestSorting(sort.(string))
+		params.WithSorting(sorting)
+	}
+
+	return params
+}
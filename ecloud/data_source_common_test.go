@@ -0,0 +1,71 @@
+package ecloud
+
+import (
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceAPIRequestParameters(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceVPC().Schema, map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{
+				"property": "name",
+				"operator": "lk",
+				"values":   []interface{}{"web-*"},
+			},
+		},
+		"sort": "created_at:desc",
+	})
+
+	params := dataSourceAPIRequestParameters(d)
+
+	assert.Equal(t, []connection.APIRequestFiltering{
+		*connection.NewAPIRequestFiltering("name", connection.LKOperator, []string{"web-*"}),
+	}, params.Filtering)
+	assert.Equal(t, connection.APIRequestSorting{Property: "created_at", Descending: true}, params.Sorting)
+}
+
+func TestDataSourceAPIRequestParameters_defaultOperator(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceVPC().Schema, map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{
+				"property": "region_id",
+				"values":   []interface{}{"reg-abcdef12", "reg-abcdef34"},
+			},
+		},
+	})
+
+	params := dataSourceAPIRequestParameters(d)
+
+	assert.Equal(t, []connection.APIRequestFiltering{
+		*connection.NewAPIRequestFiltering("region_id", connection.EQOperator, []string{"reg-abcdef12", "reg-abcdef34"}),
+	}, params.Filtering)
+	assert.Empty(t, params.Sorting.Property)
+}
+
+func TestParseDataSourceAPIRequestSorting(t *testing.T) {
+	t.Run("Property", func(t *testing.T) {
+		sorting, err := parseDataSourceAPIRequestSorting("name")
+		assert.Nil(t, err)
+		assert.Equal(t, connection.APIRequestSorting{Property: "name"}, sorting)
+	})
+
+	t.Run("Ascending", func(t *testing.T) {
+		sorting, err := parseDataSourceAPIRequestSorting("name:asc")
+		assert.Nil(t, err)
+		assert.Equal(t, connection.APIRequestSorting{Property: "name"}, sorting)
+	})
+
+	t.Run("InvalidDirection_ReturnsError", func(t *testing.T) {
+		_, err := parseDataSourceAPIRequestSorting("name:up")
+		assert.EqualError(t, err, "must be of the form <property> or <property>:<asc|desc>, got: name:up")
+	})
+
+	t.Run("EmptyProperty_ReturnsError", func(t *testing.T) {
+		_, err := parseDataSourceAPIRequestSorting(":desc")
+		assert.NotNil(t, err)
+	})
+}
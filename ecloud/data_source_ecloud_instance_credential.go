@@ -37,6 +37,8 @@ func dataSourceInstanceCredentials() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -44,7 +46,7 @@ func dataSourceInstanceCredentials() *schema.Resource {
 func dataSourceInstanceCredentialsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if username, ok := d.GetOk("username"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("username", connection.EQOperator, []string{username.(string)}))
@@ -0,0 +1,99 @@
+package ecloud
+
+import (
+	"context"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFirewallRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFirewallRulesRead,
+
+		Schema: dataSourceListSchema(
+			map[string]*schema.Schema{
+				"firewall_policy_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"direction": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateEnum(ecloudservice.FirewallRuleDirectionEnum),
+				},
+				"action": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateEnum(ecloudservice.FirewallRuleActionEnum),
+				},
+			},
+			"firewall_rules",
+			map[string]*schema.Schema{
+				"id":                 dataSourceListComputedString(),
+				"name":               dataSourceListComputedString(),
+				"firewall_policy_id": dataSourceListComputedString(),
+				"sequence":           dataSourceListComputedInt(),
+				"source":             dataSourceListComputedString(),
+				"destination":        dataSourceListComputedString(),
+				"action":             dataSourceListComputedString(),
+				"direction":          dataSourceListComputedString(),
+				"enabled": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		),
+	}
+}
+
+func dataSourceFirewallRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	params := dataSourceAPIRequestParameters(d)
+
+	if firewallPolicyID, ok := d.GetOk("firewall_policy_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("firewall_policy_id", connection.EQOperator, []string{firewallPolicyID.(string)}))
+	}
+	if name, ok := d.GetOk("name"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
+	}
+	if direction, ok := d.GetOk("direction"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("direction", connection.EQOperator, []string{direction.(string)}))
+	}
+	if action, ok := d.GetOk("action"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("action", connection.EQOperator, []string{action.(string)}))
+	}
+
+	rules, err := listAllPages(service.GetFirewallRulesPaginated, params, d.Get("limit").(int), nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving firewall rules: %s", err)
+	}
+
+	var objects []map[string]interface{}
+	for _, rule := range rules {
+		objects = append(objects, map[string]interface{}{
+			"id":                 rule.ID,
+			"name":               rule.Name,
+			"firewall_policy_id": rule.FirewallPolicyID,
+			"sequence":           rule.Sequence,
+			"source":             rule.Source,
+			"destination":        rule.Destination,
+			"action":             rule.Action.String(),
+			"direction":          rule.Direction.String(),
+			"enabled":            rule.Enabled,
+		})
+	}
+
+	if err := setDataSourceList(d, "firewall_rules", objects); err != nil {
+		return diag.Errorf("Error setting firewall rules: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package ecloud
+
+import (
+	"context"
+	"testing"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceFirewallRules_basic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	policy, _ := service.CreateFirewallPolicy(ecloudservice.CreateFirewallPolicyRequest{RouterID: "rtr-abcdef12"})
+	inRule, _ := service.CreateFirewallRule(ecloudservice.CreateFirewallRuleRequest{
+		FirewallPolicyID: policy.ResourceID,
+		Name:             "ssh",
+		Sequence:         1,
+		Direction:        ecloudservice.FirewallRuleDirectionIn,
+		Action:           ecloudservice.FirewallRuleActionAllow,
+	})
+	service.CreateFirewallRule(ecloudservice.CreateFirewallRuleRequest{
+		FirewallPolicyID: policy.ResourceID,
+		Name:             "egress",
+		Sequence:         2,
+		Direction:        ecloudservice.FirewallRuleDirectionOut,
+		Action:           ecloudservice.FirewallRuleActionAllow,
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceFirewallRules().Schema, map[string]interface{}{
+		"firewall_policy_id": policy.ResourceID,
+		"direction":          "IN",
+	})
+
+	diags := dataSourceFirewallRulesRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, []interface{}{inRule.ResourceID}, d.Get("ids"))
+	assert.Equal(t, "ALLOW", d.Get("firewall_rules.0.action"))
+}
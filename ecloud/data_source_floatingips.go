@@ -0,0 +1,81 @@
+package ecloud
+
+import (
+	"context"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFloatingIPs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFloatingIPsRead,
+
+		Schema: dataSourceListSchema(
+			map[string]*schema.Schema{
+				"vpc_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"availability_zone_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+			"floating_ips",
+			map[string]*schema.Schema{
+				"id":                   dataSourceListComputedString(),
+				"name":                 dataSourceListComputedString(),
+				"vpc_id":               dataSourceListComputedString(),
+				"availability_zone_id": dataSourceListComputedString(),
+				"ip_address":           dataSourceListComputedString(),
+				"resource_id":          dataSourceListComputedString(),
+			},
+		),
+	}
+}
+
+func dataSourceFloatingIPsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	params := dataSourceAPIRequestParameters(d)
+
+	if vpcID, ok := d.GetOk("vpc_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("vpc_id", connection.EQOperator, []string{vpcID.(string)}))
+	}
+	if azID, ok := d.GetOk("availability_zone_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("availability_zone_id", connection.EQOperator, []string{azID.(string)}))
+	}
+	if name, ok := d.GetOk("name"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
+	}
+
+	fips, err := listAllPages(service.GetFloatingIPsPaginated, params, d.Get("limit").(int), nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving floating IPs: %s", err)
+	}
+
+	var objects []map[string]interface{}
+	for _, fip := range fips {
+		objects = append(objects, map[string]interface{}{
+			"id":                   fip.ID,
+			"name":                 fip.Name,
+			"vpc_id":               fip.VPCID,
+			"availability_zone_id": fip.AvailabilityZoneID,
+			"ip_address":           fip.IPAddress,
+			"resource_id":          fip.ResourceID,
+		})
+	}
+
+	if err := setDataSourceList(d, "floating_ips", objects); err != nil {
+		return diag.Errorf("Error setting floating IPs: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package ecloud
+
+import (
+	"context"
+	"testing"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceFloatingIPs_basic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	fip, _ := service.CreateFloatingIP(ecloudservice.CreateFloatingIPRequest{VPCID: "vpc-abcdef12", Name: "web"})
+	service.CreateFloatingIP(ecloudservice.CreateFloatingIPRequest{VPCID: "vpc-other", Name: "web"})
+
+	d := schema.TestResourceDataRaw(t, dataSourceFloatingIPs().Schema, map[string]interface{}{
+		"vpc_id": "vpc-abcdef12",
+	})
+
+	diags := dataSourceFloatingIPsRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, []interface{}{fip.ResourceID}, d.Get("ids"))
+	assert.Equal(t, "web", d.Get("floating_ips.0.name"))
+}
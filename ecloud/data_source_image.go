@@ -2,12 +2,22 @@ package ecloud
 
 import (
 	"context"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/ans-group/sdk-go/pkg/connection"
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// imageOwnerSelf matches images created within a VPC of the account
+	imageOwnerSelf = "self"
+	// imageOwnerANS matches images provided by ANS, which don't belong to a VPC
+	imageOwnerANS = "ans"
 )
 
 func dataSourceImage() *schema.Resource {
@@ -20,8 +30,16 @@ func dataSourceImage() *schema.Resource {
 				Optional: true,
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name_regex"},
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				ConflictsWith:    []string{"name"},
 			},
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -35,6 +53,32 @@ func dataSourceImage() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+			},
+			"owner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{imageOwnerSelf, imageOwnerANS}, false),
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -42,7 +86,7 @@ func dataSourceImage() *schema.Resource {
 func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if id, ok := d.GetOk("image_id"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{id.(string)}))
@@ -60,13 +104,38 @@ func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, meta inter
 		params.WithFilter(*connection.NewAPIRequestFiltering("platform", connection.EQOperator, []string{platform.(string)}))
 	}
 
+	if visibility, ok := d.GetOk("visibility"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("visibility", connection.EQOperator, []string{visibility.(string)}))
+	}
+
 	images, err := service.GetImages(params)
 	if err != nil {
 		return diag.Errorf("Error retrieving active images: %s", err)
 	}
 
+	mostRecent := d.Get("most_recent").(bool)
+
 	if name, ok := d.GetOk("name"); ok {
-		images = filterImageName(images, name.(string))
+		if mostRecent {
+			images = filterImages(images, func(image ecloudservice.Image) bool {
+				return strings.EqualFold(image.Name, name.(string))
+			})
+		} else {
+			images = filterImageName(images, name.(string))
+		}
+	}
+
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		r := regexp.MustCompile(nameRegex.(string))
+		images = filterImages(images, func(image ecloudservice.Image) bool {
+			return r.MatchString(image.Name)
+		})
+	}
+
+	if owner, ok := d.GetOk("owner"); ok {
+		images = filterImages(images, func(image ecloudservice.Image) bool {
+			return (len(image.VPCID) > 0) == (owner.(string) == imageOwnerSelf)
+		})
 	}
 
 	if len(images) < 1 {
@@ -74,7 +143,11 @@ func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	if len(images) > 1 {
-		return diag.Errorf("More than 1 image found with provided arguments")
+		if !mostRecent {
+			return diag.Errorf("More than 1 image found with provided arguments. Set most_recent to use the most recently created image")
+		}
+
+		images = sortImagesMostRecent(images)
 	}
 
 	d.SetId(images[0].ID)
@@ -82,10 +155,40 @@ func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, meta inter
 	d.Set("vpc_id", images[0].VPCID)
 	d.Set("availability_zone_id", images[0].AvailabilityZoneID)
 	d.Set("platform", images[0].Platform)
+	d.Set("visibility", images[0].Visibility)
+	d.Set("created_at", images[0].CreatedAt.String())
+	d.Set("updated_at", images[0].UpdatedAt.String())
 
 	return nil
 }
 
+// filterImages returns the images for which match returns true
+func filterImages(images []ecloudservice.Image, match func(image ecloudservice.Image) bool) []ecloudservice.Image {
+	filtered := []ecloudservice.Image{}
+	for _, image := range images {
+		if match(image) {
+			filtered = append(filtered, image)
+		}
+	}
+
+	return filtered
+}
+
+// sortImagesMostRecent sorts images by creation time, most recent first, using the time of the
+// last update where images were created at the same time
+func sortImagesMostRecent(images []ecloudservice.Image) []ecloudservice.Image {
+	sort.SliceStable(images, func(i, j int) bool {
+		iCreated, jCreated := images[i].CreatedAt.Time(), images[j].CreatedAt.Time()
+		if !iCreated.Equal(jCreated) {
+			return iCreated.After(jCreated)
+		}
+
+		return images[i].UpdatedAt.Time().After(images[j].UpdatedAt.Time())
+	})
+
+	return images
+}
+
 func filterImageName(images []ecloudservice.Image, name string) []ecloudservice.Image {
 	for _, image := range images {
 		if strings.ToLower(image.Name) == strings.ToLower(name) {
@@ -1,10 +1,15 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccDataSourceImage_basic(t *testing.T) {
@@ -33,3 +38,56 @@ data "ecloud_image" "test-image" {
 }
 `, imageName)
 }
+
+func TestUnitDataSourceImage_mostRecent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	service.AddImage(ecloudservice.Image{Name: "Ubuntu 22.04", Visibility: "public", CreatedAt: "2024-01-01T00:00:00+0000"})
+	service.AddImage(ecloudservice.Image{Name: "golden-web-2024-05-06", Visibility: "private", VPCID: "vpc-abcdef12", CreatedAt: "2024-05-06T00:00:00+0000"})
+	newestID := service.AddImage(ecloudservice.Image{Name: "golden-web-2024-05-13", Visibility: "private", VPCID: "vpc-abcdef12", CreatedAt: "2024-05-13T00:00:00+0000"})
+	service.AddImage(ecloudservice.Image{Name: "golden-db-2024-05-20", Visibility: "private", VPCID: "vpc-abcdef12", CreatedAt: "2024-05-20T00:00:00+0000"})
+
+	read := func(config map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+		d := schema.TestResourceDataRaw(t, dataSourceImage().Schema, config)
+		return d, dataSourceImageRead(ctx, d, service)
+	}
+
+	t.Run("NameRegex_MostRecent", func(t *testing.T) {
+		d, diags := read(map[string]interface{}{
+			"name_regex":  "^golden-web-",
+			"visibility":  "private",
+			"most_recent": true,
+		})
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, newestID, d.Id())
+		assert.Equal(t, "golden-web-2024-05-13", d.Get("name"))
+		assert.Equal(t, "private", d.Get("visibility"))
+	})
+
+	t.Run("NameRegex_Multiple_ReturnsError", func(t *testing.T) {
+		_, diags := read(map[string]interface{}{
+			"name_regex": "^golden-web-",
+		})
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "More than 1 image found")
+	})
+
+	t.Run("Owner", func(t *testing.T) {
+		d, diags := read(map[string]interface{}{
+			"owner": "ans",
+		})
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, "Ubuntu 22.04", d.Get("name"))
+	})
+
+	t.Run("OwnerSelf_MostRecent", func(t *testing.T) {
+		d, diags := read(map[string]interface{}{
+			"owner":       "self",
+			"most_recent": true,
+		})
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, "golden-db-2024-05-20", d.Get("name"))
+	})
+}
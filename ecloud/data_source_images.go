@@ -0,0 +1,119 @@
+package ecloud
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceImages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceImagesRead,
+
+		Schema: dataSourceListSchema(
+			map[string]*schema.Schema{
+				"vpc_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"availability_zone_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"platform": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"visibility": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+				},
+				"owner": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{imageOwnerSelf, imageOwnerANS}, false),
+				},
+				"name_regex": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				},
+			},
+			"images",
+			map[string]*schema.Schema{
+				"id":                   dataSourceListComputedString(),
+				"name":                 dataSourceListComputedString(),
+				"vpc_id":               dataSourceListComputedString(),
+				"availability_zone_id": dataSourceListComputedString(),
+				"platform":             dataSourceListComputedString(),
+				"visibility":           dataSourceListComputedString(),
+				"created_at":           dataSourceListComputedString(),
+				"updated_at":           dataSourceListComputedString(),
+			},
+		),
+	}
+}
+
+func dataSourceImagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	params := dataSourceAPIRequestParameters(d)
+
+	if vpcID, ok := d.GetOk("vpc_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("vpc_id", connection.EQOperator, []string{vpcID.(string)}))
+	}
+	if azID, ok := d.GetOk("availability_zone_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("availability_zone_id", connection.EQOperator, []string{azID.(string)}))
+	}
+	if platform, ok := d.GetOk("platform"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("platform", connection.EQOperator, []string{platform.(string)}))
+	}
+	if visibility, ok := d.GetOk("visibility"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("visibility", connection.EQOperator, []string{visibility.(string)}))
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	owner := d.Get("owner").(string)
+
+	images, err := listAllPages(service.GetImagesPaginated, params, d.Get("limit").(int), func(image ecloudservice.Image) bool {
+		if nameRegex != nil && !nameRegex.MatchString(image.Name) {
+			return false
+		}
+		if owner != "" && (len(image.VPCID) > 0) != (owner == imageOwnerSelf) {
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return diag.Errorf("Error retrieving images: %s", err)
+	}
+
+	var objects []map[string]interface{}
+	for _, image := range images {
+		objects = append(objects, map[string]interface{}{
+			"id":                   image.ID,
+			"name":                 image.Name,
+			"vpc_id":               image.VPCID,
+			"availability_zone_id": image.AvailabilityZoneID,
+			"platform":             image.Platform,
+			"visibility":           image.Visibility,
+			"created_at":           image.CreatedAt.String(),
+			"updated_at":           image.UpdatedAt.String(),
+		})
+	}
+
+	if err := setDataSourceList(d, "images", objects); err != nil {
+		return diag.Errorf("Error setting images: %s", err)
+	}
+
+	return nil
+}
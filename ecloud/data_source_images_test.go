@@ -0,0 +1,31 @@
+package ecloud
+
+import (
+	"context"
+	"testing"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceImages_basic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	service.AddImage(ecloudservice.Image{Name: "Ubuntu 22.04", Visibility: "public"})
+	firstID := service.AddImage(ecloudservice.Image{Name: "golden-web-1", Visibility: "private", VPCID: "vpc-abcdef12"})
+	secondID := service.AddImage(ecloudservice.Image{Name: "golden-web-2", Visibility: "private", VPCID: "vpc-abcdef12"})
+	service.AddImage(ecloudservice.Image{Name: "golden-db-1", Visibility: "private", VPCID: "vpc-abcdef12"})
+
+	d := schema.TestResourceDataRaw(t, dataSourceImages().Schema, map[string]interface{}{
+		"owner":      "self",
+		"name_regex": "^golden-web-",
+	})
+
+	diags := dataSourceImagesRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, []interface{}{firstID, secondID}, d.Get("ids"))
+	assert.Equal(t, "private", d.Get("images.0.visibility"))
+}
@@ -26,6 +26,133 @@ func dataSourceInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"availability_zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"platform": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vcpu_cores": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vcpu": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sockets": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"cores_per_socket": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ram_capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"volume_capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volume_iops": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volume_group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"data_volume_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"host_group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_tier_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"locked": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"backup_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"backup_gateway_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"backup_agent_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"monitoring_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"monitoring_gateway_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"nics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mac_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"floating_ip_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"floating_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -46,6 +173,8 @@ func dataSourceInstance() *schema.Resource {
 					},
 				},
 			},
+			"filter": dataSourceAPIRequestFiltersSchema(),
+			"sort":   dataSourceAPIRequestSortSchema(),
 		},
 	}
 }
@@ -53,7 +182,7 @@ func dataSourceInstance() *schema.Resource {
 func dataSourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	params := connection.APIRequestParameters{}
+	params := dataSourceAPIRequestParameters(d)
 
 	if id, ok := d.GetOk("instance_id"); ok {
 		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{id.(string)}))
@@ -78,12 +207,78 @@ func dataSourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("More than 1 instance found with provided arguments")
 	}
 
-	d.SetId(instances[0].ID)
-	d.Set("vpc_id", instances[0].VPCID)
-	d.Set("name", instances[0].Name)
+	instance := instances[0]
+
+	volumes, err := service.GetInstanceVolumes(instance.ID, connection.APIRequestParameters{})
+	if err != nil {
+		return diag.Errorf("Error retrieving volumes for instance with ID [%s]: %s", instance.ID, err)
+	}
+
+	nics, err := service.GetInstanceNICs(instance.ID, connection.APIRequestParameters{})
+	if err != nil {
+		return diag.Errorf("Error retrieving NICs for instance with ID [%s]: %s", instance.ID, err)
+	}
+
+	fips, err := service.GetInstanceFloatingIPs(instance.ID, connection.APIRequestParameters{})
+	if err != nil {
+		return diag.Errorf("Error retrieving floating IPs for instance with ID [%s]: %s", instance.ID, err)
+	}
+
+	d.SetId(instance.ID)
+	d.Set("vpc_id", instance.VPCID)
+	d.Set("name", instance.Name)
+	d.Set("availability_zone_id", instance.AvailabilityZoneID)
+	d.Set("image_id", instance.ImageID)
+	d.Set("platform", instance.Platform)
+	d.Set("vcpu_cores", instance.VCPUCores)
+	d.Set("ram_capacity", instance.RAMCapacity)
+	d.Set("volume_group_id", instance.VolumeGroupID)
+	d.Set("host_group_id", instance.HostGroupID)
+	d.Set("resource_tier_id", instance.ResourceTierID)
+	d.Set("locked", instance.Locked)
+	d.Set("encrypted", instance.IsEncrypted)
+	d.Set("backup_enabled", instance.BackupEnabled)
+	d.Set("backup_gateway_id", instance.BackupGatewayID)
+	d.Set("backup_agent_enabled", instance.BackupAgentEnabled)
+	d.Set("monitoring_enabled", instance.MonitoringEnabled)
+	d.Set("monitoring_gateway_id", instance.MonitoringGatewayID)
+
+	vcpu := map[string]interface{}{
+		"sockets":          instance.VCPUSockets,
+		"cores_per_socket": instance.VCPUCoresPerSocket,
+	}
+	if err := d.Set("vcpu", []interface{}{vcpu}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, volume := range volumes {
+		if volume.Type == ecloudservice.VolumeTypeOS {
+			d.Set("volume_id", volume.ID)
+			d.Set("volume_capacity", volume.Capacity)
+			d.Set("volume_iops", volume.IOPS)
+			break
+		}
+	}
+
+	if err := d.Set("data_volume_ids", flattenInstanceDataVolumes(volumes)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("nics", flattenInstanceNICs(nics)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Instances are typically assigned a single floating IP, via their primary NIC
+	if len(fips) > 0 {
+		d.Set("floating_ip_id", fips[0].ID)
+		d.Set("floating_ip_address", fips[0].IPAddress)
+	} else {
+		d.Set("floating_ip_id", "")
+		d.Set("floating_ip_address", "")
+	}
 
 	// Set tags
-	if err := d.Set("tags", flattenInstanceTags(instances[0].Tags)); err != nil {
+	if err := d.Set("tags", flattenInstanceTags(instance.Tags)); err != nil {
 		return diag.FromErr(err)
 	}
 
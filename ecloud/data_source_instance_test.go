@@ -1,13 +1,61 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestUnitDataSourceInstance_attributes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{
+		VPCID:              "vpc-abcdef12",
+		NetworkID:          "net-abcdef12",
+		Name:               "web",
+		ImageID:            "img-abcdef12",
+		VCPUSockets:        2,
+		VCPUCoresPerSocket: 2,
+		RAMCapacity:        2048,
+		VolumeCapacity:     40,
+		VolumeIOPS:         600,
+		BackupEnabled:      true,
+		RequiresFloatingIP: true,
+		TagIDs:             []string{"tag-web"},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceInstance().Schema, map[string]interface{}{
+		"name": "web",
+	})
+
+	diags := dataSourceInstanceRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, instanceID, d.Id())
+	assert.Equal(t, "img-abcdef12", d.Get("image_id"))
+	assert.Equal(t, 4, d.Get("vcpu_cores"))
+	assert.Equal(t, 2, d.Get("vcpu.0.sockets"))
+	assert.Equal(t, 2, d.Get("vcpu.0.cores_per_socket"))
+	assert.Equal(t, 2048, d.Get("ram_capacity"))
+	assert.Equal(t, 40, d.Get("volume_capacity"))
+	assert.Equal(t, 600, d.Get("volume_iops"))
+	assert.NotEmpty(t, d.Get("volume_id"))
+	assert.Equal(t, 0, d.Get("data_volume_ids").(*schema.Set).Len())
+	assert.True(t, d.Get("backup_enabled").(bool))
+	assert.Equal(t, 1, d.Get("nics.#"))
+	assert.Equal(t, "net-abcdef12", d.Get("nics.0.network_id"))
+	assert.NotEmpty(t, d.Get("nics.0.mac_address"))
+	assert.NotEmpty(t, d.Get("floating_ip_id"))
+	assert.Equal(t, 1, d.Get("tags").(*schema.Set).Len())
+}
+
 func TestAccDataSourceInstance_basic(t *testing.T) {
 	instanceName := acctest.RandomWithPrefix("tftest")
 	config := testAccDataSourceInstanceConfig_basic(instanceName)
@@ -0,0 +1,133 @@
+package ecloud
+
+import (
+	"context"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceInstances() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceInstancesRead,
+
+		Schema: dataSourceListSchema(
+			map[string]*schema.Schema{
+				"vpc_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"image_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"tag_ids": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Set:      schema.HashString,
+				},
+			},
+			"instances",
+			map[string]*schema.Schema{
+				"id":                   dataSourceListComputedString(),
+				"name":                 dataSourceListComputedString(),
+				"vpc_id":               dataSourceListComputedString(),
+				"availability_zone_id": dataSourceListComputedString(),
+				"image_id":             dataSourceListComputedString(),
+				"vcpu_cores":           dataSourceListComputedInt(),
+				"ram_capacity":         dataSourceListComputedInt(),
+				"volume_capacity":      dataSourceListComputedInt(),
+				"volume_group_id":      dataSourceListComputedString(),
+				"host_group_id":        dataSourceListComputedString(),
+				"resource_tier_id":     dataSourceListComputedString(),
+				"tags": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id":    dataSourceListComputedString(),
+							"name":  dataSourceListComputedString(),
+							"scope": dataSourceListComputedString(),
+						},
+					},
+				},
+			},
+		),
+	}
+}
+
+func dataSourceInstancesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	params := dataSourceAPIRequestParameters(d)
+
+	if vpcID, ok := d.GetOk("vpc_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("vpc_id", connection.EQOperator, []string{vpcID.(string)}))
+	}
+	if name, ok := d.GetOk("name"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
+	}
+	if imageID, ok := d.GetOk("image_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("image_id", connection.EQOperator, []string{imageID.(string)}))
+	}
+
+	// Tags are matched once retrieved, as instances must have all of the given tags
+	var match func(ecloudservice.Instance) bool
+	if tagIDs := d.Get("tag_ids").(*schema.Set); tagIDs.Len() > 0 {
+		match = func(instance ecloudservice.Instance) bool {
+			for _, tagID := range tagIDs.List() {
+				if !instanceHasTag(instance, tagID.(string)) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	instances, err := listAllPages(service.GetInstancesPaginated, params, d.Get("limit").(int), match)
+	if err != nil {
+		return diag.Errorf("Error retrieving instances: %s", err)
+	}
+
+	var objects []map[string]interface{}
+	for _, instance := range instances {
+		objects = append(objects, map[string]interface{}{
+			"id":                   instance.ID,
+			"name":                 instance.Name,
+			"vpc_id":               instance.VPCID,
+			"availability_zone_id": instance.AvailabilityZoneID,
+			"image_id":             instance.ImageID,
+			"vcpu_cores":           instance.VCPUCores,
+			"ram_capacity":         instance.RAMCapacity,
+			"volume_capacity":      instance.VolumeCapacity,
+			"volume_group_id":      instance.VolumeGroupID,
+			"host_group_id":        instance.HostGroupID,
+			"resource_tier_id":     instance.ResourceTierID,
+			"tags":                 flattenInstanceTags(instance.Tags),
+		})
+	}
+
+	if err := setDataSourceList(d, "instances", objects); err != nil {
+		return diag.Errorf("Error setting instances: %s", err)
+	}
+
+	return nil
+}
+
+// instanceHasTag returns true if the instance has the tag with given ID
+func instanceHasTag(instance ecloudservice.Instance, tagID string) bool {
+	for _, tag := range instance.Tags {
+		if tag.ID == tagID {
+			return true
+		}
+	}
+
+	return false
+}
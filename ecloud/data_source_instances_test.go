@@ -0,0 +1,32 @@
+package ecloud
+
+import (
+	"context"
+	"testing"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceInstances_basic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	webID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12", Name: "web", TagIDs: []string{"tag-web", "tag-prod"}})
+	service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12", Name: "web-staging", TagIDs: []string{"tag-web"}})
+	service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-other", Name: "web", TagIDs: []string{"tag-web", "tag-prod"}})
+
+	d := schema.TestResourceDataRaw(t, dataSourceInstances().Schema, map[string]interface{}{
+		"vpc_id":  "vpc-abcdef12",
+		"tag_ids": []interface{}{"tag-web", "tag-prod"},
+	})
+
+	diags := dataSourceInstancesRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, []interface{}{webID}, d.Get("ids"))
+	assert.Equal(t, "web", d.Get("instances.0.name"))
+	assert.Equal(t, "tag-prod", d.Get("instances.0.tags.1.id"))
+	assert.NotEmpty(t, d.Id())
+}
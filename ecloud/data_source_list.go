@@ -0,0 +1,116 @@
+package ecloud
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceListPageSize is the number of objects requested per page by list data sources
+const dataSourceListPageSize = 100
+
+// dataSourceListSchema returns the schema of a list data source. The given arguments are combined
+// with filter, sort and limit arguments, and computed ids along with a list attribute of given
+// name holding the given attributes of each object
+func dataSourceListSchema(arguments map[string]*schema.Schema, attribute string, attributes map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"filter": dataSourceAPIRequestFiltersSchema(),
+		"sort":   dataSourceAPIRequestSortSchema(),
+		"limit": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		attribute: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: attributes,
+			},
+		},
+	}
+
+	for k, v := range arguments {
+		s[k] = v
+	}
+
+	return s
+}
+
+// dataSourceListComputedString returns the schema of a computed string attribute of a list data
+// source object
+func dataSourceListComputedString() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// dataSourceListComputedInt returns the schema of a computed int attribute of a list data source
+// object
+func dataSourceListComputedInt() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+}
+
+// listAllPages retrieves each page of objects matching params using getFunc. Where match isn't
+// nil, only objects for which it returns true are included. Pages are no longer retrieved once
+// limit objects have been found, where limit is greater than zero
+func listAllPages[T any](getFunc connection.PaginatedGetFunc[T], params connection.APIRequestParameters, limit int, match func(T) bool) ([]T, error) {
+	params.Pagination.PerPage = dataSourceListPageSize
+
+	var items []T
+	totalPages := 1
+	for page := 1; page <= totalPages; page++ {
+		params.Pagination.Page = page
+		paginated, err := getFunc(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range paginated.Items() {
+			if match != nil && !match(item) {
+				continue
+			}
+
+			items = append(items, item)
+			if limit > 0 && len(items) >= limit {
+				return items, nil
+			}
+		}
+
+		totalPages = paginated.TotalPages()
+	}
+
+	return items, nil
+}
+
+// setDataSourceList sets the ids and list attribute of given name for a list data source from
+// the flattened objects, each of which must have an id. The ID of the data source is derived from
+// the IDs of the objects
+func setDataSourceList(d *schema.ResourceData, attribute string, objects []map[string]interface{}) error {
+	ids := make([]string, 0, len(objects))
+	list := make([]interface{}, 0, len(objects))
+	for _, object := range objects {
+		ids = append(ids, object["id"].(string))
+		list = append(list, object)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+
+	return d.Set(attribute, list)
+}
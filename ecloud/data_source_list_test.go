@@ -0,0 +1,54 @@
+package ecloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAllPages(t *testing.T) {
+	service := newFakeECloudService()
+	for i := 0; i < 150; i++ {
+		service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12", Name: fmt.Sprintf("instance-%03d", i)})
+	}
+
+	pages := 0
+	getFunc := func(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.Instance], error) {
+		pages++
+		return service.GetInstancesPaginated(parameters)
+	}
+
+	t.Run("AllPages", func(t *testing.T) {
+		pages = 0
+		instances, err := listAllPages(getFunc, connection.APIRequestParameters{}, 0, nil)
+		assert.Nil(t, err)
+		assert.Len(t, instances, 150)
+		assert.Equal(t, 2, pages)
+	})
+
+	t.Run("Limit_StopsPaging", func(t *testing.T) {
+		pages = 0
+		instances, err := listAllPages(getFunc, connection.APIRequestParameters{}, 20, nil)
+		assert.Nil(t, err)
+		assert.Len(t, instances, 20)
+		assert.Equal(t, 1, pages)
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		instances, err := listAllPages(getFunc, connection.APIRequestParameters{}, 0, func(instance ecloudservice.Instance) bool {
+			return instance.Name >= "instance-140"
+		})
+		assert.Nil(t, err)
+		assert.Len(t, instances, 10)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := listAllPages(func(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.Instance], error) {
+			return nil, fmt.Errorf("test error")
+		}, connection.APIRequestParameters{}, 0, nil)
+		assert.EqualError(t, err, "test error")
+	})
+}
@@ -0,0 +1,95 @@
+package ecloud
+
+import (
+	"context"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNetworks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworksRead,
+
+		Schema: dataSourceListSchema(
+			map[string]*schema.Schema{
+				"router_id": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"vpc_id"},
+				},
+				"vpc_id": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"router_id"},
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+			"networks",
+			map[string]*schema.Schema{
+				"id":        dataSourceListComputedString(),
+				"name":      dataSourceListComputedString(),
+				"router_id": dataSourceListComputedString(),
+				"subnet":    dataSourceListComputedString(),
+			},
+		),
+	}
+}
+
+func dataSourceNetworksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	params := dataSourceAPIRequestParameters(d)
+
+	if routerID, ok := d.GetOk("router_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("router_id", connection.EQOperator, []string{routerID.(string)}))
+	}
+	if name, ok := d.GetOk("name"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
+	}
+
+	var objects []map[string]interface{}
+
+	// Networks belong to a router rather than directly to a VPC, so are filtered on the routers
+	// within the VPC
+	if vpcID, ok := d.GetOk("vpc_id"); ok {
+		routerIDs, err := vpcRouterIDs(service, vpcID.(string))
+		if err != nil {
+			return diag.Errorf("Error retrieving routers for VPC with ID [%s]: %s", vpcID.(string), err)
+		}
+
+		if len(routerIDs) < 1 {
+			if err := setDataSourceList(d, "networks", objects); err != nil {
+				return diag.Errorf("Error setting networks: %s", err)
+			}
+			return nil
+		}
+
+		params.WithFilter(*connection.NewAPIRequestFiltering("router_id", connection.INOperator, routerIDs))
+	}
+
+	networks, err := listAllPages(service.GetNetworksPaginated, params, d.Get("limit").(int), nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving networks: %s", err)
+	}
+
+	for _, network := range networks {
+		objects = append(objects, map[string]interface{}{
+			"id":        network.ID,
+			"name":      network.Name,
+			"router_id": network.RouterID,
+			"subnet":    network.Subnet,
+		})
+	}
+
+	if err := setDataSourceList(d, "networks", objects); err != nil {
+		return diag.Errorf("Error setting networks: %s", err)
+	}
+
+	return nil
+}
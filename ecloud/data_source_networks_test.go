@@ -0,0 +1,60 @@
+package ecloud
+
+import (
+	"context"
+	"testing"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceNetworks_basic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	routerID, _ := service.CreateRouter(ecloudservice.CreateRouterRequest{VPCID: "vpc-abcdef12"})
+	otherRouterID, _ := service.CreateRouter(ecloudservice.CreateRouterRequest{VPCID: "vpc-other"})
+	webID, _ := service.CreateNetwork(ecloudservice.CreateNetworkRequest{RouterID: routerID, Name: "web", Subnet: "10.0.0.0/24"})
+	dbID, _ := service.CreateNetwork(ecloudservice.CreateNetworkRequest{RouterID: routerID, Name: "db", Subnet: "10.0.1.0/24"})
+	service.CreateNetwork(ecloudservice.CreateNetworkRequest{RouterID: otherRouterID, Name: "web", Subnet: "10.0.0.0/24"})
+
+	t.Run("VPC", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceNetworks().Schema, map[string]interface{}{
+			"vpc_id": "vpc-abcdef12",
+		})
+
+		diags := dataSourceNetworksRead(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.ElementsMatch(t, []interface{}{webID, dbID}, d.Get("ids"))
+	})
+
+	t.Run("VPCWithoutRouters", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceNetworks().Schema, map[string]interface{}{
+			"vpc_id": "vpc-empty",
+		})
+
+		diags := dataSourceNetworksRead(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Empty(t, d.Get("ids"))
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceNetworks().Schema, map[string]interface{}{
+			"router_id": routerID,
+			"filter": []interface{}{
+				map[string]interface{}{
+					"property": "name",
+					"operator": "neq",
+					"values":   []interface{}{"web"},
+				},
+			},
+		})
+
+		diags := dataSourceNetworksRead(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, []interface{}{dbID}, d.Get("ids"))
+		assert.Equal(t, "10.0.1.0/24", d.Get("networks.0.subnet"))
+	})
+}
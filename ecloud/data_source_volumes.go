@@ -0,0 +1,97 @@
+package ecloud
+
+import (
+	"context"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVolumes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVolumesRead,
+
+		Schema: dataSourceListSchema(
+			map[string]*schema.Schema{
+				"vpc_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"availability_zone_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"volume_group_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+			"volumes",
+			map[string]*schema.Schema{
+				"id":                   dataSourceListComputedString(),
+				"name":                 dataSourceListComputedString(),
+				"vpc_id":               dataSourceListComputedString(),
+				"availability_zone_id": dataSourceListComputedString(),
+				"capacity":             dataSourceListComputedInt(),
+				"iops":                 dataSourceListComputedInt(),
+				"type":                 dataSourceListComputedString(),
+				"volume_group_id":      dataSourceListComputedString(),
+				"attached": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		),
+	}
+}
+
+func dataSourceVolumesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	params := dataSourceAPIRequestParameters(d)
+
+	if vpcID, ok := d.GetOk("vpc_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("vpc_id", connection.EQOperator, []string{vpcID.(string)}))
+	}
+	if azID, ok := d.GetOk("availability_zone_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("availability_zone_id", connection.EQOperator, []string{azID.(string)}))
+	}
+	if name, ok := d.GetOk("name"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
+	}
+	if volumeGroupID, ok := d.GetOk("volume_group_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("volume_group_id", connection.EQOperator, []string{volumeGroupID.(string)}))
+	}
+
+	volumes, err := listAllPages(service.GetVolumesPaginated, params, d.Get("limit").(int), nil)
+	if err != nil {
+		return diag.Errorf("Error retrieving volumes: %s", err)
+	}
+
+	var objects []map[string]interface{}
+	for _, volume := range volumes {
+		objects = append(objects, map[string]interface{}{
+			"id":                   volume.ID,
+			"name":                 volume.Name,
+			"vpc_id":               volume.VPCID,
+			"availability_zone_id": volume.AvailabilityZoneID,
+			"capacity":             volume.Capacity,
+			"iops":                 volume.IOPS,
+			"type":                 volume.Type.String(),
+			"volume_group_id":      volume.VolumeGroupID,
+			"attached":             volume.Attached,
+		})
+	}
+
+	if err := setDataSourceList(d, "volumes", objects); err != nil {
+		return diag.Errorf("Error setting volumes: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package ecloud
+
+import (
+	"context"
+	"testing"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceVolumes_basic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	volume, _ := service.CreateVolume(ecloudservice.CreateVolumeRequest{VPCID: "vpc-abcdef12", Name: "data", Capacity: 40})
+	service.CreateVolume(ecloudservice.CreateVolumeRequest{VPCID: "vpc-other", Name: "data", Capacity: 20})
+
+	d := schema.TestResourceDataRaw(t, dataSourceVolumes().Schema, map[string]interface{}{
+		"vpc_id": "vpc-abcdef12",
+	})
+
+	diags := dataSourceVolumesRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, []interface{}{volume.ResourceID}, d.Get("ids"))
+	assert.Equal(t, 40, d.Get("volumes.0.capacity"))
+}
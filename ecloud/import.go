@@ -0,0 +1,147 @@
+package ecloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parseCompositeImportID splits an import ID of the form <part>/<part>, returning an error
+// naming the expected parts if id doesn't contain exactly one non-empty value per part
+func parseCompositeImportID(id string, parts ...string) ([]string, error) {
+	values := strings.Split(id, "/")
+	if len(values) != len(parts) {
+		return nil, fmt.Errorf("Unexpected format of ID [%s], expected <%s>", id, strings.Join(parts, ">/<"))
+	}
+
+	for i, value := range values {
+		if len(value) < 1 {
+			return nil, fmt.Errorf("Unexpected format of ID [%s], %s must not be empty", id, parts[i])
+		}
+	}
+
+	return values, nil
+}
+
+// nameImportIDSeparator separates the VPC ID from the name in an import ID of the form
+// <vpc_id>:name=<name>
+const nameImportIDSeparator = ":name="
+
+// parseNameImportID parses an import ID of the form <vpc_id>:name=<name>. ok is false where
+// id isn't of this form, in which case it should be treated as the ID of the object
+func parseNameImportID(id string) (vpcID string, name string, ok bool) {
+	vpcID, name, ok = strings.Cut(id, nameImportIDSeparator)
+	if !ok || len(vpcID) < 1 || len(name) < 1 {
+		return "", "", false
+	}
+
+	return vpcID, name, true
+}
+
+// nameLookupFunc returns the IDs of objects with given name within the VPC with given ID
+type nameLookupFunc func(service ecloudservice.ECloudService, vpcID string, name string) ([]string, error)
+
+// importStateByVPCName returns an importer accepting either the ID of an object, or an ID of
+// the form <vpc_id>:name=<name> which is resolved to the ID of the single object of the given
+// name within the VPC using lookup
+func importStateByVPCName(resource string, lookup nameLookupFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		vpcID, name, ok := parseNameImportID(d.Id())
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Resolving %s by name", resource), map[string]interface{}{
+			"vpc_id": vpcID,
+			"name":   name,
+		})
+		ids, err := lookup(meta.(ecloudservice.ECloudService), vpcID, name)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving %s with name [%s] in VPC [%s]: %s", resource, name, vpcID, err)
+		}
+
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("No %s found with name [%s] in VPC [%s]", resource, name, vpcID)
+		case 1:
+			d.SetId(ids[0])
+			return []*schema.ResourceData{d}, nil
+		default:
+			return nil, fmt.Errorf("Ambiguous name [%s] in VPC [%s], matches [%s]. Import the %s using its ID instead", name, vpcID, strings.Join(ids, ", "), resource)
+		}
+	}
+}
+
+// lookupIDsByName returns a nameLookupFunc listing objects within the VPC using get. nameID
+// returns the name and ID of an object
+func lookupIDsByName[T any](get func(ecloudservice.ECloudService, connection.APIRequestParameters) ([]T, error), nameID func(T) (string, string)) nameLookupFunc {
+	return func(service ecloudservice.ECloudService, vpcID string, name string) ([]string, error) {
+		return listIDsByName(service, get, nameID, name, *connection.NewAPIRequestFiltering("vpc_id", connection.EQOperator, []string{vpcID}))
+	}
+}
+
+// lookupRouterObjectIDsByName returns a nameLookupFunc listing objects using get, for objects which
+// belong to a router rather than directly to a VPC. nameID returns the name and ID of an object
+func lookupRouterObjectIDsByName[T any](get func(ecloudservice.ECloudService, connection.APIRequestParameters) ([]T, error), nameID func(T) (string, string)) nameLookupFunc {
+	return func(service ecloudservice.ECloudService, vpcID string, name string) ([]string, error) {
+		routerIDs, err := vpcRouterIDs(service, vpcID)
+		if err != nil || len(routerIDs) < 1 {
+			return nil, err
+		}
+
+		return listIDsByName(service, get, nameID, name, *connection.NewAPIRequestFiltering("router_id", connection.INOperator, routerIDs))
+	}
+}
+
+// listIDsByName returns the IDs of objects listed using get with given name and filter. Names
+// are compared exactly, as the API filter may not be
+func listIDsByName[T any](service ecloudservice.ECloudService, get func(ecloudservice.ECloudService, connection.APIRequestParameters) ([]T, error), nameID func(T) (string, string), name string, filter connection.APIRequestFiltering) ([]string, error) {
+	objects, err := get(service, nameFilterParameters(name, filter))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, object := range objects {
+		if objectName, id := nameID(object); objectName == name {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// nameFilterParameters returns request parameters filtering on name along with given filters
+func nameFilterParameters(name string, filters ...connection.APIRequestFiltering) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name}))
+	for _, filter := range filters {
+		params.WithFilter(filter)
+	}
+
+	return params
+}
+
+// vpcRouterIDs returns the IDs of routers within the VPC with given ID, for resolving objects
+// which belong to a router rather than directly to a VPC
+func vpcRouterIDs(service ecloudservice.ECloudService, vpcID string) ([]string, error) {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering("vpc_id", connection.EQOperator, []string{vpcID}))
+
+	routers, err := service.GetRouters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, router := range routers {
+		ids = append(ids, router.ID)
+	}
+
+	return ids, nil
+}
@@ -2,20 +2,34 @@ package ecloud
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/ans-group/sdk-go/pkg/client"
 	"github.com/ans-group/sdk-go/pkg/config"
 	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/logging"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/limiter"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/lock"
 	"github.com/ukfast/terraform-provider-ecloud/pkg/logger"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/poll"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/retry"
 )
 
 const userAgent = "terraform-provider-ecloud"
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"context": {
 				Type:        schema.TypeString,
@@ -28,6 +42,112 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				Description: "API token to authenticate with UKFast APIs. See https://developers.ukfast.io for more details",
 			},
+			"api_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ANS_API_ENDPOINT", ""),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Base URL of the API, e.g. https://api.ukfast.io. Defaults to the public API endpoint",
+			},
+			"api_ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ANS_API_CA_CERT_FILE", ""),
+				Description: "Path to a PEM-encoded CA certificate bundle used to verify the API endpoint",
+			},
+			"api_insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ANS_API_INSECURE", false),
+				Description: "Skip TLS certificate verification of the API endpoint",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a failed API request is retried. Set to 0 to disable retries",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum wait in seconds before retrying a failed API request",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum wait in seconds between retries of a failed API request",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests in flight at once across all resources. Set to 0 for no limit",
+			},
+			"lock_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ANS_LOCK_DIR", ""),
+				Description: "Directory for advisory lock files, used to serialise operations on shared resources across concurrent Terraform runs on the same host",
+			},
+			"default_timeouts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Default create, update and delete timeouts for all resources, overridden by a timeouts block on a resource",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"create": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDuration,
+						},
+						"update": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDuration,
+						},
+						"delete": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDuration,
+						},
+					},
+				},
+			},
+			"poll_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Wait in seconds before first polling a task or resource for completion of an operation",
+			},
+			"poll_min_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Minimum wait in seconds between polls for completion of an operation, doubled (with jitter) for each subsequent poll",
+			},
+			"poll_max_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum wait in seconds between polls for completion of an operation",
+			},
+			"poll_not_found_checks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of consecutive polls which must find a resource no longer exists before a delete is considered complete",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"ecloud_volume":                    dataSourceVolume(),
@@ -71,43 +191,65 @@ func Provider() *schema.Provider {
 			"ecloud_backup_gateway":            dataSourceBackupGateway(),
 			"ecloud_monitoring_gateway":        dataSourceMonitoringGateway(),
 			"ecloud_tag":                       dataSourceTag(),
+			"ecloud_instances":                 dataSourceInstances(),
+			"ecloud_networks":                  dataSourceNetworks(),
+			"ecloud_volumes":                   dataSourceVolumes(),
+			"ecloud_images":                    dataSourceImages(),
+			"ecloud_floatingips":               dataSourceFloatingIPs(),
+			"ecloud_firewallrules":             dataSourceFirewallRules(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"ecloud_vpc":                   resourceVPC(),
-			"ecloud_router":                resourceRouter(),
-			"ecloud_network":               resourceNetwork(),
-			"ecloud_image":                 resourceImage(),
-			"ecloud_instance":              resourceInstance(),
-			"ecloud_ipaddress":             resourceIPAddress(),
-			"ecloud_firewallpolicy":        resourceFirewallPolicy(),
-			"ecloud_firewallrule":          resourceFirewallRule(),
-			"ecloud_volume":                resourceVolume(),
-			"ecloud_floatingip":            resourceFloatingIP(),
-			"ecloud_hostgroup":             resourceHostGroup(),
-			"ecloud_host":                  resourceHost(),
-			"ecloud_ssh_keypair":           resourceSshKeyPair(),
-			"ecloud_networkpolicy":         resourceNetworkPolicy(),
-			"ecloud_networkrule":           resourceNetworkRule(),
-			"ecloud_nic_ipaddress_binding": resourceNICIPAddressBinding(),
-			"ecloud_vpn_service":           resourceVPNService(),
-			"ecloud_vpn_endpoint":          resourceVPNEndpoint(),
-			"ecloud_vpn_session":           resourceVPNSession(),
-			"ecloud_vpn_gateway":           resourceVPNGateway(),
-			"ecloud_vpn_gateway_user":      resourceVPNGatewayUser(),
-			"ecloud_volumegroup":           resourceVolumeGroup(),
-			"ecloud_loadbalancer":          resourceLoadBalancer(),
-			"ecloud_loadbalancer_vip":      resourceLoadBalancerVip(),
-			"ecloud_affinityrule":          resourceAffinityRule(),
-			"ecloud_affinityrule_member":   resourceAffinityRuleMember(),
-			"ecloud_natoverloadrule":       resourceNATOverloadRule(),
-			"ecloud_volumegroup_instance":  resourceVolumeGroupInstance(),
-			"ecloud_instance_script":       resourceInstanceScript(),
-			"ecloud_backup_gateway":        resourceBackupGateway(),
-			"ecloud_nic":                   resourceNIC(),
-			"ecloud_tag":                   resourceTag(),
+			"ecloud_vpc":                          resourceVPC(),
+			"ecloud_router":                       resourceRouter(),
+			"ecloud_network":                      resourceNetwork(),
+			"ecloud_image":                        resourceImage(),
+			"ecloud_instance":                     resourceInstance(),
+			"ecloud_ipaddress":                    resourceIPAddress(),
+			"ecloud_firewallpolicy":               resourceFirewallPolicy(),
+			"ecloud_firewallrule":                 resourceFirewallRule(),
+			"ecloud_volume":                       resourceVolume(),
+			"ecloud_floatingip":                   resourceFloatingIP(),
+			"ecloud_hostgroup":                    resourceHostGroup(),
+			"ecloud_host":                         resourceHost(),
+			"ecloud_ssh_keypair":                  resourceSshKeyPair(),
+			"ecloud_networkpolicy":                resourceNetworkPolicy(),
+			"ecloud_networkrule":                  resourceNetworkRule(),
+			"ecloud_nic_ipaddress_binding":        resourceNICIPAddressBinding(),
+			"ecloud_vpn_service":                  resourceVPNService(),
+			"ecloud_vpn_endpoint":                 resourceVPNEndpoint(),
+			"ecloud_vpn_session":                  resourceVPNSession(),
+			"ecloud_vpn_gateway":                  resourceVPNGateway(),
+			"ecloud_vpn_gateway_user":             resourceVPNGatewayUser(),
+			"ecloud_volumegroup":                  resourceVolumeGroup(),
+			"ecloud_loadbalancer":                 resourceLoadBalancer(),
+			"ecloud_loadbalancer_vip":             resourceLoadBalancerVip(),
+			"ecloud_affinityrule":                 resourceAffinityRule(),
+			"ecloud_affinityrule_member":          resourceAffinityRuleMember(),
+			"ecloud_natoverloadrule":              resourceNATOverloadRule(),
+			"ecloud_volumegroup_instance":         resourceVolumeGroupInstance(),
+			"ecloud_instance_script":              resourceInstanceScript(),
+			"ecloud_instance_credential_rotation": resourceInstanceCredentialRotation(),
+			"ecloud_backup_gateway":               resourceBackupGateway(),
+			"ecloud_nic":                          resourceNIC(),
+			"ecloud_tag":                          resourceTag(),
 		},
-		ConfigureContextFunc: providerConfigure,
 	}
+
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		meta, diags := providerConfigure(ctx, d)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		err := configureDefaultTimeouts(provider.ResourcesMap, d.Get("default_timeouts").([]interface{}))
+		if err != nil {
+			return nil, diag.Errorf("Error configuring default_timeouts: %s", err)
+		}
+
+		return meta, diags
+	}
+
+	return provider
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -133,18 +275,186 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		config.Set(config.GetCurrentContextName(), "api_key", apiKey)
 	}
 
-	conn, err := getConnection()
+	conn, err := getConnection(connectionConfig{
+		APIEndpoint: d.Get("api_endpoint").(string),
+		CACertFile:  d.Get("api_ca_cert_file").(string),
+		Insecure:    d.Get("api_insecure").(bool),
+	})
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	return client.NewClient(conn).ECloudService(), nil
+	// Limit concurrency beneath retries, so that a request waiting to be retried doesn't hold a slot
+	if maxConcurrent := d.Get("max_concurrent_requests").(int); maxConcurrent > 0 {
+		conn = limiter.NewConnection(conn, limiter.NewLimiter(maxConcurrent))
+	}
+
+	if maxRetries := d.Get("max_retries").(int); maxRetries > 0 {
+		retryConfig := retry.Config{
+			MaxRetries: maxRetries,
+			WaitMin:    time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+			WaitMax:    time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+		}
+		if retryConfig.WaitMax < retryConfig.WaitMin {
+			return nil, diag.Errorf("retry_wait_max [%d] must be greater than or equal to retry_wait_min [%d]", d.Get("retry_wait_max").(int), d.Get("retry_wait_min").(int))
+		}
+
+		conn = retry.NewConnection(conn, retryConfig)
+	}
+
+	if lockDir := d.Get("lock_dir").(string); len(lockDir) > 0 {
+		err := lock.UseFileLocks(lockDir)
+		if err != nil {
+			return nil, diag.Errorf("Error configuring lock_dir: %s", err)
+		}
+	}
+
+	pollConfig := poll.DefaultConfig
+	pollConfig.Delay = time.Duration(d.Get("poll_delay").(int)) * time.Second
+	pollConfig.MinInterval = time.Duration(d.Get("poll_min_interval").(int)) * time.Second
+	pollConfig.MaxInterval = time.Duration(d.Get("poll_max_interval").(int)) * time.Second
+	pollConfig.NotFoundChecks = d.Get("poll_not_found_checks").(int)
+	if pollConfig.MaxInterval < pollConfig.MinInterval {
+		return nil, diag.Errorf("poll_max_interval [%d] must be greater than or equal to poll_min_interval [%d]", d.Get("poll_max_interval").(int), d.Get("poll_min_interval").(int))
+	}
+
+	return &providerMeta{
+		ECloudService: client.NewClient(conn).ECloudService(),
+		poller:        poll.NewPoller(pollConfig),
+	}, nil
 }
 
-func getConnection() (connection.Connection, error) {
+// configureDefaultTimeouts overrides the default timeouts of resources with those from the
+// default_timeouts block. Only operations for which a resource declares a timeout are overridden
+func configureDefaultTimeouts(resources map[string]*schema.Resource, l []interface{}) error {
+	if len(l) < 1 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	durations := make(map[string]*time.Duration)
+	for _, key := range []string{"create", "update", "delete"} {
+		v, ok := m[key].(string)
+		if !ok || len(v) < 1 {
+			continue
+		}
+
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s timeout [%s]: %s", key, v, err)
+		}
+		durations[key] = &duration
+	}
+
+	for _, r := range resources {
+		if r.Timeouts == nil {
+			continue
+		}
+
+		if r.Timeouts.Create != nil && durations["create"] != nil {
+			r.Timeouts.Create = schema.DefaultTimeout(*durations["create"])
+		}
+		if r.Timeouts.Update != nil && durations["update"] != nil {
+			r.Timeouts.Update = schema.DefaultTimeout(*durations["update"])
+		}
+		if r.Timeouts.Delete != nil && durations["delete"] != nil {
+			r.Timeouts.Delete = schema.DefaultTimeout(*durations["delete"])
+		}
+	}
+
+	return nil
+}
+
+func validateDuration(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := time.ParseDuration(val.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a valid duration, e.g. 30m or 1h: %s", key, err))
+	}
+	return
+}
+
+// providerMeta is passed to resources as meta. It embeds the configured ECloudService, so
+// resources continue to assert meta to ecloudservice.ECloudService, alongside provider-level
+// settings
+type providerMeta struct {
+	ecloudservice.ECloudService
+
+	poller *poll.Poller
+}
+
+// Poller returns the poller used to wait for operations to complete
+func (m *providerMeta) Poller() *poll.Poller {
+	return m.poller
+}
+
+var defaultPoller = poll.NewPoller(poll.DefaultConfig)
+
+// getPoller returns the poller configured for service, falling back to the default poller
+// where service wasn't configured by the provider
+func getPoller(service ecloudservice.ECloudService) *poll.Poller {
+	if p, ok := service.(interface{ Poller() *poll.Poller }); ok {
+		return p.Poller()
+	}
+
+	return defaultPoller
+}
+
+// connectionConfig holds provider-level overrides for the API connection
+type connectionConfig struct {
+	APIEndpoint string
+	CACertFile  string
+	Insecure    bool
+}
+
+func getConnection(cfg connectionConfig) (connection.Connection, error) {
 	connFactory := connection.NewDefaultConnectionFactory(
 		connection.WithDefaultConnectionUserAgent(userAgent),
 	)
 
-	return connFactory.NewConnection()
+	conn, err := connFactory.NewConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	apiConn, ok := conn.(*connection.APIConnection)
+	if !ok {
+		return conn, nil
+	}
+
+	if len(cfg.APIEndpoint) > 0 {
+		endpoint, err := url.Parse(cfg.APIEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api_endpoint: %s", err)
+		}
+
+		apiConn.APIScheme = endpoint.Scheme
+		apiConn.APIURI = strings.TrimRight(endpoint.Host+endpoint.Path, "/")
+	}
+
+	if len(cfg.CACertFile) > 0 || cfg.Insecure {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: cfg.Insecure,
+		}
+
+		if len(cfg.CACertFile) > 0 {
+			caCert, err := os.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read api_ca_cert_file: %s", err)
+			}
+
+			certPool, err := x509.SystemCertPool()
+			if err != nil {
+				certPool = x509.NewCertPool()
+			}
+			if !certPool.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("no valid PEM certificates found in api_ca_cert_file [%s]", cfg.CACertFile)
+			}
+			tlsConfig.RootCAs = certPool
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		apiConn.HTTPClient.Transport = transport
+	}
+
+	return apiConn, nil
 }
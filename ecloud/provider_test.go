@@ -2,12 +2,24 @@ package ecloud
 
 import (
 	"bytes"
+	"context"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"text/template"
+	"time"
 
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var testAccProviders map[string]func() (*schema.Provider, error)
@@ -33,6 +45,233 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProvider_apiEndpoint(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ecloud/v2/vpcs/vpc-abcdef12" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":"vpc-abcdef12","name":"test-vpc"},"meta":{}}`))
+	}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0600); err != nil {
+		t.Fatalf("failed to write CA certificate: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_key":          "test",
+		"api_endpoint":     server.URL,
+		"api_ca_cert_file": caCertFile,
+	})
+
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	vpc, err := meta.(ecloudservice.ECloudService).GetVPC("vpc-abcdef12")
+	if err != nil {
+		t.Fatalf("unexpected error retrieving VPC: %s", err)
+	}
+
+	if vpc.Name != "test-vpc" {
+		t.Fatalf("expected VPC name [test-vpc], got [%s]", vpc.Name)
+	}
+}
+
+func TestProvider_apiEndpoint_invalidCACert(t *testing.T) {
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte("invalid"), 0600); err != nil {
+		t.Fatalf("failed to write CA certificate: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_key":          "test",
+		"api_endpoint":     "https://127.0.0.1",
+		"api_ca_cert_file": caCertFile,
+	})
+
+	_, diags := providerConfigure(context.Background(), d)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "api_ca_cert_file") {
+		t.Fatalf("expected api_ca_cert_file error, got: %v", diags)
+	}
+}
+
+func TestProvider_retry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":"task-abcdef12","status":"complete"},"meta":{}}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_key":        "test",
+		"api_endpoint":   server.URL,
+		"retry_wait_min": 0,
+	})
+
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	task, err := meta.(ecloudservice.ECloudService).GetTask("task-abcdef12")
+	if err != nil {
+		t.Fatalf("unexpected error retrieving task: %s", err)
+	}
+
+	if task.Status != ecloudservice.TaskStatusComplete || requests != 3 {
+		t.Fatalf("expected complete task after 3 requests, got status [%s] after %d requests", task.Status, requests)
+	}
+}
+
+func TestProvider_maxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"id":"task-abcdef12","status":"complete"},"meta":{}}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_key":                 "test",
+		"api_endpoint":            server.URL,
+		"max_concurrent_requests": 2,
+	})
+
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := meta.(ecloudservice.ECloudService).GetTask("task-abcdef12"); err != nil {
+				t.Errorf("unexpected error retrieving task: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestProvider_lockDir_invalid(t *testing.T) {
+	lockDir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(lockDir, []byte{}, 0600); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_key":  "test",
+		"lock_dir": lockDir,
+	})
+
+	_, diags := providerConfigure(context.Background(), d)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Error configuring lock_dir: failed to create lock directory") {
+		t.Fatalf("expected lock_dir error, got: %v", diags)
+	}
+}
+
+func TestProvider_poll(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_key":               "test",
+		"poll_delay":            1,
+		"poll_min_interval":     2,
+		"poll_max_interval":     20,
+		"poll_not_found_checks": 3,
+	})
+
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	config := getPoller(meta.(ecloudservice.ECloudService)).Config()
+	if config.Delay != time.Second || config.MinInterval != 2*time.Second || config.MaxInterval != 20*time.Second || config.NotFoundChecks != 3 {
+		t.Fatalf("unexpected poll config: %+v", config)
+	}
+}
+
+func TestProvider_poll_invalidInterval(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_key":           "test",
+		"poll_min_interval": 10,
+		"poll_max_interval": 5,
+	})
+
+	_, diags := providerConfigure(context.Background(), d)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "poll_max_interval") {
+		t.Fatalf("expected poll_max_interval error, got: %v", diags)
+	}
+}
+
+func TestProvider_poll_zeroInterval(t *testing.T) {
+	for _, key := range []string{"poll_min_interval", "poll_max_interval"} {
+		diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"api_key": "test",
+			key:       0,
+		}))
+		if !diags.HasError() {
+			t.Fatalf("expected %s error", key)
+		}
+	}
+}
+
+func TestProvider_defaultTimeouts(t *testing.T) {
+	p := Provider()
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_key": "test",
+		"default_timeouts": []interface{}{
+			map[string]interface{}{
+				"create": "45m",
+				"delete": "1h",
+			},
+		},
+	})
+
+	_, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error configuring provider: %v", diags)
+	}
+
+	timeouts := p.ResourcesMap["ecloud_vpn_gateway"].Timeouts
+	if *timeouts.Create != 45*time.Minute || *timeouts.Update != 30*time.Minute || *timeouts.Delete != time.Hour {
+		t.Fatalf("unexpected timeouts: create [%s], update [%s], delete [%s]", timeouts.Create, timeouts.Update, timeouts.Delete)
+	}
+
+	if p.ResourcesMap["ecloud_nic_ipaddress_binding"].Timeouts.Update != nil {
+		t.Fatalf("expected update timeout to remain unset for resource without update")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	testAccPreCheckRequiredEnvVars(t)
 }
@@ -43,6 +282,18 @@ func testAccPreCheckRequiredEnvVars(t *testing.T) {
 	// }
 }
 
+// testUnitPreCheck skips unit tests which drive the Terraform CLI when no binary is available,
+// as the test framework would otherwise attempt to download one
+func testUnitPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform CLI not found in PATH, set TF_ACC_TERRAFORM_PATH to run unit tests")
+	}
+}
+
 func testAccTemplateConfig(t string, i interface{}) (string, error) {
 	tmpl, err := template.New("output").Parse(t)
 	if err != nil {
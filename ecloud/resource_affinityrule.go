@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +22,12 @@ func resourceAffinityRule() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -88,17 +93,16 @@ func resourceAffinityRuleCreate(ctx context.Context, d *schema.ResourceData, met
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "affinity rule",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       AffinityRuleSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for affinity rule with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	// add members to rule
@@ -125,17 +129,16 @@ func resourceAffinityRuleCreate(ctx context.Context, d *schema.ResourceData, met
 				return diag.Errorf("Error creating affinity rule member: %s", err)
 			}
 
-			stateConf := &resource.StateChangeConf{
-				Target:     []string{ecloudservice.TaskStatusComplete.String()},
-				Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "create",
+				Resource:   "affinity rule member",
+				ResourceID: taskRef.ResourceID,
+				TaskID:     taskRef.TaskID,
+				Sync:       AffinityRuleMemberSyncFunc(service, taskRef.ResourceID),
 				Timeout:    d.Timeout(schema.TimeoutCreate),
-				Delay:      10 * time.Second,
-				MinTimeout: 5 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for affinity rule member with ID [%s] to be created: %s", d.Id(), err)
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
@@ -190,17 +193,16 @@ func resourceAffinityRuleUpdate(ctx context.Context, d *schema.ResourceData, met
 			return diag.Errorf("Error updating affinity rule with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "affinity rule",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       AffinityRuleSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      3 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for affinity rule with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -241,17 +243,16 @@ func resourceAffinityRuleUpdate(ctx context.Context, d *schema.ResourceData, met
 				return diag.Errorf("Error deleting affinity rule member: %s", err)
 			}
 
-			stateConf := &resource.StateChangeConf{
-				Target:     []string{ecloudservice.TaskStatusComplete.String()},
-				Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-				Timeout:    d.Timeout(schema.TimeoutDelete),
-				Delay:      10 * time.Second,
-				MinTimeout: 5 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for affinity rule member for instance ID [%s] to be delete: %s", instanceID, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "delete",
+				Resource:   "affinity rule member",
+				ResourceID: ruleMemberID,
+				TaskID:     taskID,
+				Sync:       AffinityRuleMemberSyncFunc(service, ruleMemberID),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 
@@ -277,17 +278,16 @@ func resourceAffinityRuleUpdate(ctx context.Context, d *schema.ResourceData, met
 				return diag.Errorf("Error creating affinity rule member: %s", err)
 			}
 
-			stateConf := &resource.StateChangeConf{
-				Target:     []string{ecloudservice.TaskStatusComplete.String()},
-				Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
-				Timeout:    d.Timeout(schema.TimeoutCreate),
-				Delay:      10 * time.Second,
-				MinTimeout: 5 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for affinity rule member for instance ID [%s] to be created: %s", instanceID, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "create",
+				Resource:   "affinity rule member",
+				ResourceID: taskRef.ResourceID,
+				TaskID:     taskRef.TaskID,
+				Sync:       AffinityRuleMemberSyncFunc(service, taskRef.ResourceID),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 
 		}
@@ -338,21 +338,22 @@ func resourceAffinityRuleDelete(ctx context.Context, d *schema.ResourceData, met
 						"affinity_rule_id": arMembers[0].ID,
 						"instance_member":  instanceID,
 					})
+					continue
 				default:
 					return diag.Errorf("Error removing affinity rule member ID [%s] for instance ID [%s]: %s", arMembers[0].ID, instanceID, err)
 				}
 			}
 
-			stateConf := &resource.StateChangeConf{
-				Target:     []string{ecloudservice.TaskStatusComplete.String()},
-				Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "delete",
+				Resource:   "affinity rule member",
+				ResourceID: arMembers[0].ID,
+				TaskID:     taskID,
+				Sync:       AffinityRuleMemberSyncFunc(service, arMembers[0].ID),
 				Timeout:    d.Timeout(schema.TimeoutDelete),
-				Delay:      5 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for affinity rule member ID [%s] for instance ID [%s] to be removed: %s", arMembers[0].ID, instanceID, err)
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
@@ -370,18 +371,32 @@ func resourceAffinityRuleDelete(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "affinity rule",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       AffinityRuleSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for affinity rule with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// AffinityRuleSyncFunc returns a ResourceSyncFunc retrieving the sync state of the affinity rule with given ID
+func AffinityRuleSyncFunc(service ecloudservice.ECloudService, ruleID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		affinityRule, err := service.GetAffinityRule(ruleID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.AffinityRuleNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &affinityRule.Sync, nil
+	}
+}
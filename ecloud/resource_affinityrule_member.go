@@ -3,12 +3,13 @@ package ecloud
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ans-group/sdk-go/pkg/connection"
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/ukfast/terraform-provider-ecloud/pkg/lock"
 )
@@ -20,7 +21,13 @@ func resourceAffinityRuleMember() *schema.Resource {
 		UpdateContext: resourceAffinityRuleMemberUpdate,
 		DeleteContext: resourceAffinityRuleMemberDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceAffinityRuleMemberImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,7 +50,10 @@ func resourceAffinityRuleMemberCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("Invalid affinity rule ID: %s", ruleID)
 	}
 
-	unlock := lock.LockResource(ruleID)
+	unlock, err := lock.LockResource(ctx, ruleID)
+	if err != nil {
+		return diag.Errorf("Error locking affinity rule with ID [%s]: %s", ruleID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -63,17 +73,16 @@ func resourceAffinityRuleMemberCreate(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "affinity rule member",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       AffinityRuleMemberSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for affinity rule member with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceAffinityRuleMemberRead(ctx, d, meta)
@@ -109,7 +118,10 @@ func resourceAffinityRuleMemberUpdate(ctx context.Context, d *schema.ResourceDat
 func resourceAffinityRuleMemberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	ruleID := d.Get("affinity_rule_id").(string)
 
-	unlock := lock.LockResource(ruleID)
+	unlock, err := lock.LockResource(ctx, ruleID)
+	if err != nil {
+		return diag.Errorf("Error locking affinity rule with ID [%s]: %s", ruleID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -127,18 +139,71 @@ func resourceAffinityRuleMemberDelete(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "affinity rule member",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       AffinityRuleMemberSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+	})
+	if diags.HasError() {
+		return diags
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	return nil
+}
+
+// resourceAffinityRuleMemberImport imports a member using an ID of the form
+// <affinity_rule_id>/<instance_id>, or the ID of the member itself
+func resourceAffinityRuleMemberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	service := meta.(ecloudservice.ECloudService)
+
+	if !strings.Contains(d.Id(), "/") {
+		_, err := service.GetAffinityRuleMember(d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving affinity rule member with ID [%s]: %s", d.Id(), err)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts, err := parseCompositeImportID(d.Id(), "affinity_rule_id", "instance_id")
 	if err != nil {
-		return diag.Errorf("Error waiting for affinity rule member with ID [%s] to be deleted: %s", d.Id(), err)
+		return nil, err
 	}
+	ruleID, instanceID := parts[0], parts[1]
 
-	return nil
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering("instance_id", connection.EQOperator, []string{instanceID}))
+
+	members, err := service.GetAffinityRuleMembers(ruleID, params)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving members of affinity rule with ID [%s]: %s", ruleID, err)
+	}
+
+	if len(members) != 1 {
+		return nil, fmt.Errorf("Instance with ID [%s] is not a member of affinity rule with ID [%s]", instanceID, ruleID)
+	}
+
+	d.SetId(members[0].ID)
+	d.Set("affinity_rule_id", ruleID)
+	d.Set("instance_id", instanceID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// AffinityRuleMemberSyncFunc returns a ResourceSyncFunc retrieving the sync state of the affinity rule member with given ID
+func AffinityRuleMemberSyncFunc(service ecloudservice.ECloudService, memberID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		affinityRuleMember, err := service.GetAffinityRuleMember(memberID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.AffinityRuleMemberNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &affinityRuleMember.Sync, nil
+	}
 }
@@ -1,6 +1,7 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccAffinityRuleMember_basic(t *testing.T) {
@@ -131,3 +133,52 @@ resource "ecloud_affinityrule_member" "test-arm" {
 }
 `, affinityRuleName, affinityRuleMemberInstanceID)
 }
+
+func TestUnitAffinityRuleMember_import(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	rule, _ := service.CreateAffinityRule(ecloudservice.CreateAffinityRuleRequest{VPCID: "vpc-abcdef12"})
+	member, _ := service.CreateAffinityRuleMember(ecloudservice.CreateAffinityRuleMemberRequest{
+		AffinityRuleID: rule.ResourceID,
+		InstanceID:     "i-abcdef12",
+	})
+
+	t.Run("CompositeID", func(t *testing.T) {
+		d := resourceAffinityRuleMember().TestResourceData()
+		d.SetId(rule.ResourceID + "/i-abcdef12")
+
+		result, err := resourceAffinityRuleMemberImport(ctx, d, service)
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, member.ResourceID, d.Id())
+		assert.Equal(t, rule.ResourceID, d.Get("affinity_rule_id"))
+		assert.Equal(t, "i-abcdef12", d.Get("instance_id"))
+	})
+
+	t.Run("MemberID", func(t *testing.T) {
+		d := resourceAffinityRuleMember().TestResourceData()
+		d.SetId(member.ResourceID)
+
+		_, err := resourceAffinityRuleMemberImport(ctx, d, service)
+		assert.Nil(t, err)
+		assert.Equal(t, member.ResourceID, d.Id())
+	})
+
+	t.Run("NotMember_ReturnsError", func(t *testing.T) {
+		d := resourceAffinityRuleMember().TestResourceData()
+		d.SetId(rule.ResourceID + "/i-00000000")
+
+		_, err := resourceAffinityRuleMemberImport(ctx, d, service)
+		assert.EqualError(t, err, fmt.Sprintf("Instance with ID [i-00000000] is not a member of affinity rule with ID [%s]", rule.ResourceID))
+	})
+
+	t.Run("InvalidID_ReturnsError", func(t *testing.T) {
+		d := resourceAffinityRuleMember().TestResourceData()
+		d.SetId(rule.ResourceID + "/")
+
+		_, err := resourceAffinityRuleMemberImport(ctx, d, service)
+		assert.EqualError(t, err, fmt.Sprintf("Unexpected format of ID [%s/], instance_id must not be empty", rule.ResourceID))
+	})
+}
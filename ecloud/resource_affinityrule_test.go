@@ -1,13 +1,18 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ans-group/sdk-go/pkg/connection"
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccAffinityRule_basic(t *testing.T) {
@@ -103,3 +108,57 @@ resource "ecloud_affinityrule" "test-ar" {
 }
 `, affinityRuleName, affinityRuleType)
 }
+
+func TestUnitAffinityRule_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+
+	d := schema.TestResourceDataRaw(t, resourceAffinityRule().Schema, map[string]interface{}{
+		"vpc_id":               "vpc-abcdef12",
+		"availability_zone_id": "az-abcdef12",
+		"name":                 "test-rule",
+		"type":                 "anti-affinity",
+		"instance_ids":         []interface{}{instanceID},
+	})
+
+	diags := resourceAffinityRuleCreate(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.NotEmpty(t, d.Id())
+	assert.True(t, d.Get("instance_ids").(*schema.Set).Contains(instanceID))
+
+	members, err := service.GetAffinityRuleMembers(d.Id(), connection.APIRequestParameters{})
+	assert.Nil(t, err)
+	assert.Len(t, members, 1)
+
+	diags = resourceAffinityRuleDelete(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+	_, err = service.GetAffinityRule(d.Id())
+	assert.IsType(t, &ecloudservice.AffinityRuleNotFoundError{}, err)
+}
+
+func TestUnitAffinityRule_taskFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	taskRef, _ := service.CreateAffinityRule(ecloudservice.CreateAffinityRuleRequest{VPCID: "vpc-abcdef12"})
+	service.Fail(taskRef.ResourceID)
+
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "affinity rule",
+		ResourceID: taskRef.ResourceID,
+		TaskID:     taskRef.TaskID,
+		Sync:       AffinityRuleSyncFunc(service, taskRef.ResourceID),
+		Timeout:    time.Minute,
+	})
+	assert.True(t, diags.HasError())
+	assert.Equal(t, fmt.Sprintf("Error waiting to create affinity rule with ID [%s]", taskRef.ResourceID), diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "has status of failed")
+	assert.Contains(t, diags[0].Detail, fmt.Sprintf("Task [%s] (affinity_rule_create) for resource [%s] has status [failed]", taskRef.TaskID, taskRef.ResourceID))
+	assert.Contains(t, diags[0].Detail, fmt.Sprintf("The affinity rule [%s] has sync status [failed]", taskRef.ResourceID))
+}
@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceBackupGateway() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -65,17 +70,16 @@ func resourceBackupGatewayCreate(ctx context.Context, d *schema.ResourceData, me
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "backup gateway",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       BackupGatewaySyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for backup gateway with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceBackupGatewayRead(ctx, d, meta)
@@ -122,17 +126,16 @@ func resourceBackupGatewayUpdate(ctx context.Context, d *schema.ResourceData, me
 			return diag.Errorf("Error updating backup gateway with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "backup gateway",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       BackupGatewaySyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for backup gateway with ID [%s] to be updated: %s", d.Id(), err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -155,18 +158,32 @@ func resourceBackupGatewayDelete(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "backup gateway",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       BackupGatewaySyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for backup gateway with ID [%s] to be removed: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// BackupGatewaySyncFunc returns a ResourceSyncFunc retrieving the sync state of the backup gateway with given ID
+func BackupGatewaySyncFunc(service ecloudservice.ECloudService, gatewayID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		gateway, err := service.GetBackupGateway(gatewayID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.BackupGatewayNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &gateway.Sync, nil
+	}
+}
@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -20,7 +19,15 @@ func resourceFirewallPolicy() *schema.Resource {
 		UpdateContext: resourceFirewallPolicyUpdate,
 		DeleteContext: resourceFirewallPolicyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByVPCName("firewall policy", lookupRouterObjectIDsByName(ecloudservice.ECloudService.GetFirewallPolicies, func(policy ecloudservice.FirewallPolicy) (string, string) {
+				return policy.Name, policy.ID
+			})),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -60,17 +67,16 @@ func resourceFirewallPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(policy.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    FirewallPolicySyncStatusRefreshFunc(service, policy.ResourceID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "firewall policy",
+		ResourceID: policy.ResourceID,
+		TaskID:     policy.TaskID,
+		Sync:       FirewallPolicySyncFunc(service, policy.ResourceID),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for firewall policy with ID [%s] to return sync status of [%s]: %s", policy.ResourceID, ecloudservice.SyncStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceFirewallPolicyRead(ctx, d, meta)
@@ -120,22 +126,21 @@ func resourceFirewallPolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 		tflog.Info(ctx, "Updating firewall policy", map[string]interface{}{
 			"id": d.Id(),
 		})
-		_, err := service.PatchFirewallPolicy(d.Id(), patchReq)
+		task, err := service.PatchFirewallPolicy(d.Id(), patchReq)
 		if err != nil {
 			return diag.Errorf("Error updating firewall policy with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    FirewallPolicySyncStatusRefreshFunc(service, d.Id()),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "firewall policy",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       FirewallPolicySyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 1 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for firewall policy with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.SyncStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -148,43 +153,42 @@ func resourceFirewallPolicyDelete(ctx context.Context, d *schema.ResourceData, m
 	tflog.Info(ctx, "Removing firewall policy", map[string]interface{}{
 		"id": d.Id(),
 	})
-	_, err := service.DeleteFirewallPolicy(d.Id())
+	taskID, err := service.DeleteFirewallPolicy(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing firewall policy with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.FirewallPolicyNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing firewall policy with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"Deleted"},
-		Refresh:    FirewallPolicySyncStatusRefreshFunc(service, d.Id()),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "firewall policy",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       FirewallPolicySyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for firewall policy with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
-// FirewallPolicySyncStatusRefreshFunc returns a function with StateRefreshFunc signature for use
-// with StateChangeConf
-func FirewallPolicySyncStatusRefreshFunc(service ecloudservice.ECloudService, policyID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		policy, err := service.GetFirewallPolicy(policyID)
+// FirewallPolicySyncFunc returns a ResourceSyncFunc retrieving the sync state of the firewall policy with given ID
+func FirewallPolicySyncFunc(service ecloudservice.ECloudService, policyID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		firewallPolicy, err := service.GetFirewallPolicy(policyID)
 		if err != nil {
 			if _, ok := err.(*ecloudservice.FirewallPolicyNotFoundError); ok {
-				return policy, "Deleted", nil
+				return nil, nil
 			}
-			return nil, "", err
-		}
-
-		if policy.Sync.Status == ecloudservice.SyncStatusFailed {
-			return nil, "", fmt.Errorf("Failed to create/update firewall policy - review logs")
+			return nil, err
 		}
 
-		return policy, policy.Sync.Status.String(), nil
+		return &firewallPolicy.Sync, nil
 	}
 }
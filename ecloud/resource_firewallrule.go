@@ -10,7 +10,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/ukfast/terraform-provider-ecloud/pkg/lock"
 )
@@ -25,6 +24,12 @@ func resourceFirewallRule() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"firewall_policy_id": {
 				Type:     schema.TypeString,
@@ -41,12 +46,14 @@ func resourceFirewallRule() *schema.Resource {
 				Required: true,
 			},
 			"direction": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(ecloudservice.FirewallRuleDirectionEnum),
 			},
 			"action": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(ecloudservice.FirewallRuleActionEnum),
 			},
 			"source": {
 				Type:     schema.TypeString,
@@ -66,8 +73,9 @@ func resourceFirewallRule() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"protocol": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateEnum(ecloudservice.FirewallRulePortProtocolEnum),
 						},
 						"source": {
 							Type:     schema.TypeString,
@@ -86,7 +94,10 @@ func resourceFirewallRule() *schema.Resource {
 
 func resourceFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	firewallPolicyID := d.Get("firewall_policy_id").(string)
-	unlock := lock.LockResource(firewallPolicyID)
+	unlock, err := lock.LockResource(ctx, firewallPolicyID)
+	if err != nil {
+		return diag.Errorf("Error locking firewall policy with ID [%s]: %s", firewallPolicyID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -130,17 +141,17 @@ func resourceFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, met
 
 	d.SetId(rule.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    FirewallPolicySyncStatusRefreshFunc(service, firewallPolicyID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for firewall policy with ID [%s] to return sync status of [%s]: %s", firewallPolicyID, ecloudservice.SyncStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:    "create",
+		Resource:     "firewall rule",
+		ResourceID:   rule.ResourceID,
+		TaskID:       rule.TaskID,
+		Sync:         FirewallPolicySyncFunc(service, firewallPolicyID),
+		SyncResource: fmt.Sprintf("firewall policy [%s]", firewallPolicyID),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceFirewallRuleRead(ctx, d, meta)
@@ -186,7 +197,10 @@ func resourceFirewallRuleRead(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	firewallPolicyID := d.Get("firewall_policy_id").(string)
-	unlock := lock.LockResource(firewallPolicyID)
+	unlock, err := lock.LockResource(ctx, firewallPolicyID)
+	if err != nil {
+		return diag.Errorf("Error locking firewall policy with ID [%s]: %s", firewallPolicyID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -259,22 +273,22 @@ func resourceFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, met
 			"id": d.Id(),
 		})
 
-		_, err := service.PatchFirewallRule(d.Id(), patchReq)
+		task, err := service.PatchFirewallRule(d.Id(), patchReq)
 		if err != nil {
 			return diag.Errorf("Error updating firewall rule with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    FirewallPolicySyncStatusRefreshFunc(service, firewallPolicyID),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 1 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for firewall policy with ID [%s] to return sync status of [%s]: %s", firewallPolicyID, ecloudservice.SyncStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:    "update",
+			Resource:     "firewall rule",
+			ResourceID:   d.Id(),
+			TaskID:       task.TaskID,
+			Sync:         FirewallPolicySyncFunc(service, firewallPolicyID),
+			SyncResource: fmt.Sprintf("firewall policy [%s]", firewallPolicyID),
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -283,7 +297,10 @@ func resourceFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, met
 
 func resourceFirewallRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	firewallPolicyID := d.Get("firewall_policy_id").(string)
-	unlock := lock.LockResource(firewallPolicyID)
+	unlock, err := lock.LockResource(ctx, firewallPolicyID)
+	if err != nil {
+		return diag.Errorf("Error locking firewall policy with ID [%s]: %s", firewallPolicyID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -291,22 +308,27 @@ func resourceFirewallRuleDelete(ctx context.Context, d *schema.ResourceData, met
 	tflog.Info(ctx, "Removing firewall rule", map[string]interface{}{
 		"id": d.Id(),
 	})
-	_, err := service.DeleteFirewallRule(d.Id())
+	taskID, err := service.DeleteFirewallRule(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing firewall rule with ID [%s]: %s", d.Id(), err)
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    FirewallPolicySyncStatusRefreshFunc(service, firewallPolicyID),
-		Timeout:    d.Timeout(schema.TimeoutUpdate),
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
+		switch err.(type) {
+		case *ecloudservice.FirewallRuleNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing firewall rule with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for firewall policy with ID [%s] to return sync status of [%s]: %s", firewallPolicyID, ecloudservice.SyncStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:    "delete",
+		Resource:     "firewall rule",
+		ResourceID:   d.Id(),
+		TaskID:       taskID,
+		Sync:         FirewallPolicySyncFunc(service, firewallPolicyID),
+		SyncResource: fmt.Sprintf("firewall policy [%s]", firewallPolicyID),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
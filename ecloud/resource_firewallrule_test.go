@@ -1,13 +1,17 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/ans-group/sdk-go/pkg/connection"
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccFirewallRule_basic(t *testing.T) {
@@ -134,3 +138,123 @@ resource "ecloud_firewallrule" "test-fwr" {
 
 	return str
 }
+
+func TestUnitFirewallRule_basic(t *testing.T) {
+	testUnitPreCheck(t)
+	t.Parallel()
+
+	service := newFakeECloudService()
+	resourceName := "ecloud_firewallrule.test-fwr"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testUnitProviderFactories(service),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitResourceFirewallRuleConfig_basic("ALLOW"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "action", "ALLOW"),
+					resource.TestCheckResourceAttr(resourceName, "port.0.protocol", "TCP"),
+				),
+			},
+			{
+				Config: testUnitResourceFirewallRuleConfig_basic("DROP"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "action", "DROP"),
+			},
+		},
+	})
+}
+
+func TestUnitFirewallRule_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	policy, _ := service.CreateFirewallPolicy(ecloudservice.CreateFirewallPolicyRequest{RouterID: "rtr-abcdef12"})
+
+	d := schema.TestResourceDataRaw(t, resourceFirewallRule().Schema, map[string]interface{}{
+		"firewall_policy_id": policy.ResourceID,
+		"name":               "test-rule",
+		"sequence":           1,
+		"direction":          "IN",
+		"action":             "ALLOW",
+		"source":             "10.0.0.0/24",
+		"destination":        "ANY",
+		"enabled":            true,
+		"port": []interface{}{
+			map[string]interface{}{"protocol": "TCP", "source": "ANY", "destination": "443"},
+		},
+	})
+
+	diags := resourceFirewallRuleCreate(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.NotEmpty(t, d.Id())
+
+	rule, err := service.GetFirewallRule(d.Id())
+	assert.Nil(t, err)
+	assert.Equal(t, ecloudservice.FirewallRuleDirectionIn, rule.Direction)
+
+	ports, err := service.GetFirewallRuleFirewallRulePorts(d.Id(), connection.APIRequestParameters{})
+	assert.Nil(t, err)
+	assert.Len(t, ports, 1)
+	assert.Equal(t, "443", ports[0].Destination)
+
+	diags = resourceFirewallRuleDelete(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+	_, err = service.GetFirewallRule(d.Id())
+	assert.IsType(t, &ecloudservice.FirewallRuleNotFoundError{}, err)
+
+	diags = resourceFirewallRuleRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Empty(t, d.Id())
+}
+
+func TestUnitFirewallRule_policySyncFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	policy, _ := service.CreateFirewallPolicy(ecloudservice.CreateFirewallPolicyRequest{RouterID: "rtr-abcdef12"})
+	service.Fail(policy.ResourceID)
+
+	d := schema.TestResourceDataRaw(t, resourceFirewallRule().Schema, map[string]interface{}{
+		"firewall_policy_id": policy.ResourceID,
+		"sequence":           1,
+		"direction":          "IN",
+		"action":             "ALLOW",
+		"source":             "ANY",
+		"destination":        "ANY",
+	})
+
+	diags := resourceFirewallRuleCreate(ctx, d, service)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, fmt.Sprintf("Error waiting to create firewall rule with ID [%s]", d.Id()), diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "(firewall_rule_create)")
+	assert.Contains(t, diags[0].Detail, fmt.Sprintf("The firewall policy [%s] has sync status [failed]", policy.ResourceID))
+}
+
+func testUnitResourceFirewallRuleConfig_basic(action string) string {
+	return fmt.Sprintf(`
+resource "ecloud_firewallpolicy" "test-fwp" {
+	router_id = "rtr-abcdef12"
+	sequence = 0
+}
+
+resource "ecloud_firewallrule" "test-fwr" {
+	firewall_policy_id = ecloud_firewallpolicy.test-fwp.id
+	name = "test-rule"
+	sequence = 0
+	direction = "IN"
+	action = "%s"
+	source = "10.0.0.0/24"
+	destination = "ANY"
+	enabled = true
+
+	port {
+		protocol = "TCP"
+		source = "ANY"
+		destination = "443"
+	}
+}
+`, action)
+}
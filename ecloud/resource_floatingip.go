@@ -10,7 +10,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -24,6 +23,12 @@ func resourceFloatingIP() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -87,17 +92,16 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "floating IP",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       FloatingIPSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      3 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for floating IP with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	if r, ok := d.GetOk("resource_id"); ok {
@@ -125,17 +129,16 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error assigning floating IP: %s", err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "assign",
+			Resource:   "floating IP",
+			ResourceID: d.Id(),
+			TaskID:     taskID,
+			Sync:       FloatingIPSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      3 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for floating IP with ID [%s] to be assigned: %s", d.Id(), err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 	return resourceFloatingIPRead(ctx, d, meta)
@@ -182,17 +185,16 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error updating floating ip with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "floating IP",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       FloatingIPSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      3 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for floating ip with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -244,17 +246,16 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, meta
 				return diag.Errorf("Error unassigning floating ip with ID [%s]: %s", d.Id(), err)
 			}
 
-			stateConf := &resource.StateChangeConf{
-				Target:     []string{ecloudservice.TaskStatusComplete.String()},
-				Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "unassign",
+				Resource:   "floating IP",
+				ResourceID: d.Id(),
+				TaskID:     taskID,
+				Sync:       FloatingIPSyncFunc(service, d.Id()),
 				Timeout:    d.Timeout(schema.TimeoutUpdate),
-				Delay:      3 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for floating ip with ID [%s] to be unassigned: %s", d.Id(), err)
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 
@@ -275,17 +276,16 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, meta
 				return diag.Errorf("Error assigning floating IP: %s", err)
 			}
 
-			stateConf := &resource.StateChangeConf{
-				Target:     []string{ecloudservice.TaskStatusComplete.String()},
-				Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-				Timeout:    d.Timeout(schema.TimeoutCreate),
-				Delay:      3 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for floating IP with ID [%s] to be assigned: %s", d.Id(), err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "assign",
+				Resource:   "floating IP",
+				ResourceID: d.Id(),
+				TaskID:     taskID,
+				Sync:       FloatingIPSyncFunc(service, d.Id()),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
@@ -302,17 +302,16 @@ func resourceFloatingIPDelete(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error unassigning floating ip with ID [%s]: %s", d.Id(), err)
 		}
 
-		unassignStateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      3 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = unassignStateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for floating ip with ID [%s] to be unassigned: %s", d.Id(), err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "unassign",
+			Resource:   "floating IP",
+			ResourceID: d.Id(),
+			TaskID:     taskID,
+			Sync:       FloatingIPSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutDelete),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -330,17 +329,16 @@ func resourceFloatingIPDelete(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "floating IP",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       FloatingIPSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for floating ip with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
@@ -364,3 +362,18 @@ func getNICDHCPAddress(service ecloudservice.ECloudService, nicID string) (eclou
 
 	return ipAddresses[0], nil
 }
+
+// FloatingIPSyncFunc returns a ResourceSyncFunc retrieving the sync state of the floating IP with given ID
+func FloatingIPSyncFunc(service ecloudservice.ECloudService, fipID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		floatingIP, err := service.GetFloatingIP(fipID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.FloatingIPNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &floatingIP.Sync, nil
+	}
+}
@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -37,6 +36,8 @@ func resourceHost() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 	}
 }
@@ -59,17 +60,16 @@ func resourceHostCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	d.SetId(task.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "host",
+		ResourceID: d.Id(),
+		TaskID:     task.TaskID,
+		Sync:       HostSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for host group with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceHostRead(ctx, d, meta)
@@ -114,17 +114,16 @@ func resourceHostUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 			return diag.Errorf("Error updating host with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "host",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       HostSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for host group with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -147,18 +146,32 @@ func resourceHostDelete(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "host",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       HostSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for host with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// HostSyncFunc returns a ResourceSyncFunc retrieving the sync state of the host with given ID
+func HostSyncFunc(service ecloudservice.ECloudService, hostID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		host, err := service.GetHost(hostID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.HostNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &host.Sync, nil
+	}
+}
@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceHostGroup() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -71,17 +76,16 @@ func resourceHostGroupCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	d.SetId(task.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "host group",
+		ResourceID: d.Id(),
+		TaskID:     task.TaskID,
+		Sync:       HostGroupSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for host group with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceHostGroupRead(ctx, d, meta)
@@ -129,17 +133,16 @@ func resourceHostGroupUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			return diag.Errorf("Error updating host group with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "host group",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       HostGroupSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for host group with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -162,18 +165,32 @@ func resourceHostGroupDelete(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "host group",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       HostGroupSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for host group with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// HostGroupSyncFunc returns a ResourceSyncFunc retrieving the sync state of the host group with given ID
+func HostGroupSyncFunc(service ecloudservice.ECloudService, hostGroupID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		hostGroup, err := service.GetHostGroup(hostGroupID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.HostGroupNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &hostGroup.Sync, nil
+	}
+}
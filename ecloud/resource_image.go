@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceImage() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
 				Type:     schema.TypeString,
@@ -61,17 +66,16 @@ func resourceImageCreate(ctx context.Context, d *schema.ResourceData, meta inter
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "image",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       ImageSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskRef.TaskID, ecloudservice.TaskStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceImageRead(ctx, d, meta)
@@ -121,17 +125,16 @@ func resourceImageUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 			return diag.Errorf("Error updating image with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskRef.TaskID, ecloudservice.SyncStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "image",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       ImageSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -146,21 +149,40 @@ func resourceImageDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	})
 	taskID, err := service.DeleteImage(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing image with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.ImageNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing image with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "image",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       ImageSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for image with ID [%s] to be deleted: %s", taskID, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// ImageSyncFunc returns a ResourceSyncFunc retrieving the sync state of the image with given ID
+func ImageSyncFunc(service ecloudservice.ECloudService, imageID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		image, err := service.GetImage(imageID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.ImageNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &image.Sync, nil
+	}
+}
@@ -1,7 +1,10 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ans-group/sdk-go/pkg/connection"
@@ -14,21 +17,26 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/ukfast/terraform-provider-ecloud/pkg/lock"
-	"golang.org/x/net/context"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/poll"
 )
 
 func resourceInstance() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		CreateContext: resourceInstanceCreate,
 		ReadContext:   resourceInstanceRead,
 		UpdateContext: resourceInstanceUpdate,
 		DeleteContext: resourceInstanceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByVPCName("instance", lookupIDsByName(ecloudservice.ECloudService.GetInstances, func(instance ecloudservice.Instance) (string, string) {
+				return instance.Name, instance.ID
+			})),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
+		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -59,7 +67,7 @@ func resourceInstance() *schema.Resource {
 				Type:          schema.TypeInt,
 				Optional:      true,
 				Computed:      true,
-				Deprecated:    "Use the vcpu block instead. To migrate, set vcpu.sockets to your current vcpu_cores value, and vcpu.cores_per_socket to 1.",
+				Deprecated:    "Use the vcpu block instead. Existing state is upgraded to the vcpu block using the instance's current topology, which should be copied into the configuration.",
 				ConflictsWith: []string{"vcpu"},
 				AtLeastOneOf:  []string{"vcpu_cores", "vcpu"},
 			},
@@ -67,6 +75,7 @@ func resourceInstance() *schema.Resource {
 				Type:          schema.TypeList,
 				MaxItems:      1,
 				Optional:      true,
+				Computed:      true,
 				ConflictsWith: []string{"vcpu_cores"},
 				AtLeastOneOf:  []string{"vcpu_cores", "vcpu"},
 				ValidateFunc:  nil,
@@ -133,9 +142,42 @@ func resourceInstance() *schema.Resource {
 				Computed: true,
 			},
 			"network_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"network_interface"},
+			},
+			"network_interface": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				MinItems:      1,
+				ConflictsWith: []string{"network_id", "ip_address", "floating_ip_id", "requires_floating_ip"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ip_address": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true,
+							ValidateDiagFunc: validateIPAddress,
+						},
+						"floating_ip_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mac_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"nic_id": {
 				Type:     schema.TypeString,
@@ -190,9 +232,9 @@ func resourceInstance() *schema.Resource {
 				Computed: true,
 			},
 			"ip_address": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"network_interface"},
 			},
 			"encrypted": {
 				Type:     schema.TypeBool,
@@ -226,25 +268,52 @@ func resourceInstance() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
+			"power_state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{instancePowerStateOnline, instancePowerStateOffline}, false)),
+			},
+			"graceful_shutdown": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"force_power_off": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"resize_strategy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          instanceResizeStrategyHot,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{instanceResizeStrategyHot, instanceResizeStrategyShutdownRestart}, false)),
+			},
+			"shutdown_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "5m",
+				ValidateFunc: validateDuration,
+			},
 		},
 		CustomizeDiff: customdiff.Sequence(
-			// If we're using the new vcpu block in the configuration, then we need to remove vcpu_cores
-			// from the diff, as supplying vcpu_cores along with the sockets/cores_per_socket options is
-			// not allowed by the API.
-			customdiff.If(
-				func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
-					if _, ok := d.GetOk("vcpu"); ok {
-						return true
-					}
-					return false
-				},
-				func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
-					// Set vcpu_cores to zero value to remove it from the API call
-					return d.SetNew("vcpu_cores", 0)
-				},
-			),
+			resourceInstanceCustomizeDiffVCPU,
+			resourceInstanceCustomizeDiffCapacity,
+			resourceInstanceCustomizeDiffNetworkInterfaces,
 		),
 	}
+
+	// The schema is unchanged in version 1, only the way vcpu is held in state
+	resource.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceInstanceV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceInstanceStateUpgradeV0,
+		},
+	}
+
+	return resource
 }
 
 func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -291,6 +360,14 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta in
 		createReq.VCPUCoresPerSocket = coresPerSocket
 	}
 
+	// the first network interface is created along with the instance
+	networkInterfaces := d.Get("network_interface").([]interface{})
+	if len(networkInterfaces) > 0 {
+		primary := networkInterfaces[0].(map[string]interface{})
+		createReq.NetworkID = primary["network_id"].(string)
+		createReq.CustomIPAddress = connection.IPAddress(primary["ip_address"].(string))
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Created CreateInstanceRequest: %+v", createReq))
 
 	tflog.Info(ctx, "Creating Instance")
@@ -301,14 +378,22 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	d.SetId(instanceID)
 
-	_, err = waitForResourceState(
-		ctx,
-		ecloudservice.SyncStatusComplete.String(),
-		InstanceSyncStatusRefreshFunc(service, instanceID),
-		d.Timeout(schema.TimeoutCreate),
-	)
-	if err != nil {
-		return diag.Errorf("Error waiting for instance with ID [%s] to return sync status of [%s]: %s", instanceID, ecloudservice.SyncStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "instance",
+		ResourceID: instanceID,
+		Sync:       InstanceSyncFunc(service, instanceID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(networkInterfaces) > 0 {
+		diags := resourceInstanceUpdateNetworkInterfaces(ctx, service, d, nil, networkInterfaces, d.Timeout(schema.TimeoutCreate))
+		if diags.HasError() {
+			return diags
+		}
 	}
 
 	// attach data volumes
@@ -333,14 +418,16 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta in
 				return diag.Errorf("Error attaching volume: %s", err)
 			}
 
-			_, err = waitForResourceState(
-				ctx,
-				ecloudservice.TaskStatusComplete.String(),
-				TaskStatusRefreshFunc(ctx, service, taskID),
-				d.Timeout(schema.TimeoutUpdate),
-			)
-			if err != nil {
-				return diag.Errorf("Error waiting for volume with ID [%s] to be attached: %s", volumeID, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "attach",
+				Resource:   "volume",
+				ResourceID: volumeID,
+				TaskID:     taskID,
+				Sync:       VolumeSyncFunc(service, volumeID),
+				Timeout:    d.Timeout(schema.TimeoutCreate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
@@ -357,16 +444,26 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta in
 			return diag.Errorf("Error attaching volume: %s", err)
 		}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.SyncStatusComplete.String(),
-			InstanceSyncStatusRefreshFunc(service, d.Id()),
-			d.Timeout(schema.TimeoutUpdate),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for volumegroup with ID [%s] to be attached: %s", volumeGroupID, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "instance",
+			ResourceID: d.Id(),
+			Sync:       InstanceSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutCreate),
+		})
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// instances are online once created
+	if d.Get("power_state").(string) == instancePowerStateOffline {
+		diags := resourceInstanceSetPowerState(ctx, service, d, instancePowerStateOffline, d.Timeout(schema.TimeoutCreate))
+		if diags.HasError() {
+			return diags
 		}
 	}
+
 	return resourceInstanceRead(ctx, d, meta)
 }
 
@@ -389,7 +486,13 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	volumes, err := service.GetInstanceVolumes(d.Id(), connection.APIRequestParameters{})
 	if err != nil {
-		return diag.Errorf("Failed to retrieve instance volumes: %s", err)
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			d.SetId("")
+			return nil
+		default:
+			return diag.Errorf("Failed to retrieve instance volumes: %s", err)
+		}
 	}
 
 	// check we have 1 os volume
@@ -420,30 +523,46 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta inte
 	d.Set("volume_group_id", instance.VolumeGroupID)
 	d.Set("encrypted", instance.IsEncrypted)
 
+	if instance.Online != nil {
+		d.Set("power_state", instancePowerState(*instance.Online))
+	}
+
+	vcpu := map[string]interface{}{
+		"sockets":          instance.VCPUSockets,
+		"cores_per_socket": instance.VCPUCoresPerSocket,
+	}
+	d.Set("vcpu", []interface{}{vcpu})
+
 	if _, ok := d.GetOk("vcpu_cores"); ok {
 		d.Set("vcpu_cores", instance.VCPUCores)
 	} else {
-		vcpu := map[string]interface{}{
-			"sockets":          instance.VCPUSockets,
-			"cores_per_socket": instance.VCPUCoresPerSocket,
-		}
-		d.Set("vcpu", []interface{}{vcpu})
-
 		// Can't use vcpu_cores once we switch to sockets/cores_per_socket
 		d.Set("vcpu_cores", nil)
 	}
 
-	if d.Get("nic_id").(string) == "" {
-		nics, err := service.GetInstanceNICs(d.Id(), connection.APIRequestParameters{})
-		if err != nil {
-			return diag.Errorf("Failed to retrieve instance nics: %s", err)
-		}
+	nics, err := service.GetInstanceNICs(d.Id(), connection.APIRequestParameters{})
+	if err != nil {
+		return diag.Errorf("Failed to retrieve instance nics: %s", err)
+	}
+
+	// The API doesn't identify the primary NIC, so it can only be determined where the instance has
+	// a single NIC, unless already held in state
+	if d.Get("nic_id").(string) == "" && len(d.Get("network_interface").([]interface{})) < 1 && len(nics) > 1 {
+		return diag.Errorf("Unexpected number of instance nics. Unable to lookup floating ip")
+	}
 
-		if len(nics) > 1 {
+	if err := resourceInstanceReadNetworkInterfaces(service, d, nics); err != nil {
+		return diag.Errorf("Failed to retrieve instance network interfaces: %s", err)
+	}
+
+	if d.Get("nic_id").(string) == "" {
+		// the primary NIC is always the first network interface
+		networkInterfaces := d.Get("network_interface").([]interface{})
+		if len(networkInterfaces) < 1 {
 			return diag.Errorf("Unexpected number of instance nics. Unable to lookup floating ip")
 		}
 
-		d.Set("nic_id", nics[0].ID)
+		d.Set("nic_id", networkInterfaces[0].(map[string]interface{})["id"])
 	}
 
 	if d.Get("requires_floating_ip").(bool) {
@@ -492,15 +611,17 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		hasChange = true
 		patchReq.Name = d.Get("name").(string)
 	}
-	if d.HasChange("vcpu_cores") {
+	if d.HasChanges("vcpu_cores", "vcpu") {
 		hasChange = true
-		patchReq.VCPUCores = d.Get("vcpu_cores").(int)
-	}
-	if d.HasChange("vcpu") {
-		hasChange = true
-		sockets, coresPerSocket := expandVCPUConfig(d.Get("vcpu").([]interface{}))
-		patchReq.VCPUSockets = sockets
-		patchReq.VCPUCoresPerSocket = coresPerSocket
+		// vcpu_cores is zeroed by CustomizeDiff where the vcpu block is in use, as the API doesn't
+		// allow it to be supplied along with sockets/cores_per_socket
+		if vcpuCores := d.Get("vcpu_cores").(int); vcpuCores > 0 {
+			patchReq.VCPUCores = vcpuCores
+		} else {
+			sockets, coresPerSocket := expandVCPUConfig(d.Get("vcpu").([]interface{}))
+			patchReq.VCPUSockets = sockets
+			patchReq.VCPUCoresPerSocket = coresPerSocket
+		}
 	}
 	if d.HasChange("ram_capacity") {
 		hasChange = true
@@ -536,25 +657,45 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		patchReq.TagIDs = &tagIDs
 	}
 
-	if hasChange {
-		tflog.Debug(ctx, fmt.Sprintf("Created PatchInstanceRequest: %+v", patchReq))
+	// Guests which can't hot-add vCPU and RAM are shut down while resized, and powered back on
+	// once the instance and its volume have been updated
+	restart := false
+	if d.Get("resize_strategy").(string) == instanceResizeStrategyShutdownRestart && d.HasChanges("vcpu_cores", "vcpu", "ram_capacity", "volume_capacity") {
+		oldPowerState, _ := d.GetChange("power_state")
+		restart = oldPowerState.(string) != instancePowerStateOffline
+	}
 
-		tflog.Info(ctx, "Updating instance", map[string]interface{}{
+	// The shutdown, resize and restart share the update timeout
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutUpdate))
+
+	if restart {
+		tflog.Info(ctx, "Shutting down instance to resize", map[string]interface{}{
 			"id": d.Id(),
 		})
-		err := service.PatchInstance(d.Id(), patchReq)
-		if err != nil {
-			return diag.Errorf("Error updating instance with ID [%s]: %s", d.Id(), err)
+		diags := resourceInstanceSetPowerState(ctx, service, d, instancePowerStateOffline, timeoutRemaining(deadline))
+		if diags.HasError() {
+			return diags
 		}
+	}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.SyncStatusComplete.String(),
-			InstanceSyncStatusRefreshFunc(service, d.Id()),
-			d.Timeout(schema.TimeoutUpdate),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for instance with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.SyncStatusComplete, err)
+	diags := resourceInstanceResize(ctx, service, d, hasChange, patchReq, deadline)
+	if diags.HasError() {
+		if restart && d.Get("power_state").(string) != instancePowerStateOffline {
+			diags = append(diags, resourceInstanceStartAfterFailedResize(ctx, service, d)...)
+		}
+		return diags
+	}
+
+	if restart {
+		if d.Get("power_state").(string) == instancePowerStateOffline {
+			tflog.Info(ctx, "Leaving resized instance powered off", map[string]interface{}{
+				"id": d.Id(),
+			})
+		} else {
+			diags := resourceInstanceStartAfterResize(ctx, service, d, timeoutRemaining(deadline))
+			if diags.HasError() {
+				return diags
+			}
 		}
 	}
 
@@ -569,15 +710,17 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			"new_value": newFip,
 		})
 
+		unlock, err := lock.LockResources(ctx, oldFip, newFip)
+		if err != nil {
+			return diag.Errorf("Error locking floating IPs: %s", err)
+		}
+		defer unlock()
+
 		if len(newFip) < 1 && oldFip != "" {
-			// lock the fip
 			if len(oldFip) < 1 {
 				return diag.Errorf("invalid floating ip ID: %s", oldFip)
 			}
 
-			unlock := lock.LockResource(oldFip)
-			defer unlock()
-
 			tflog.Debug(ctx, "Unassigning floating IP", map[string]interface{}{
 				"fip_id": oldFip,
 			})
@@ -595,14 +738,16 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 				}
 			}
 
-			_, err = waitForResourceState(
-				ctx,
-				ecloudservice.TaskStatusComplete.String(),
-				TaskStatusRefreshFunc(ctx, service, taskID),
-				d.Timeout(schema.TimeoutDelete),
-			)
-			if err != nil {
-				return diag.Errorf("Error waiting for floating ip with ID [%s] to be unassigned: %s", oldFip, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "unassign",
+				Resource:   "floating IP",
+				ResourceID: oldFip,
+				TaskID:     taskID,
+				Sync:       FloatingIPSyncFunc(service, oldFip),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 
 			// unset floating ip
@@ -610,14 +755,10 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 
 		if oldFip == "" && newFip != "" {
-			// lock the fip
 			if len(newFip) < 1 {
 				return diag.Errorf("invalid floating ip ID: %s", newFip)
 			}
 
-			unlock := lock.LockResource(newFip)
-			defer unlock()
-
 			tflog.Debug(ctx, "Assigning floating IP", map[string]interface{}{
 				"fip_id": newFip,
 			})
@@ -632,62 +773,50 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 				return diag.Errorf("Error assigning floating IP: %s", err)
 			}
 
-			_, err = waitForResourceState(
-				ctx,
-				ecloudservice.TaskStatusComplete.String(),
-				TaskStatusRefreshFunc(ctx, service, taskID),
-				d.Timeout(schema.TimeoutUpdate),
-			)
-			if err != nil {
-				return diag.Errorf("Error waiting for floating IP with ID [%s] to be assigned: %s", newFip, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "assign",
+				Resource:   "floating IP",
+				ResourceID: newFip,
+				TaskID:     taskID,
+				Sync:       FloatingIPSyncFunc(service, newFip),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
 
-	// manage volume capacity
-	if d.HasChange("volume_capacity") {
+	if d.HasChange("volume_iops") {
 		osVolumeID := d.Get("volume_id").(string)
 		tflog.Info(ctx, "Updating volume", map[string]interface{}{
 			"volume_id": osVolumeID,
 		})
 		task, err := service.PatchVolume(osVolumeID, ecloudservice.PatchVolumeRequest{
-			Capacity: d.Get("volume_capacity").(int),
+			IOPS: d.Get("volume_iops").(int),
 		})
 		if err != nil {
 			return diag.Errorf("Error updating volume with ID [%s]: %s", osVolumeID, err)
 		}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.TaskStatusComplete.String(),
-			TaskStatusRefreshFunc(ctx, service, task.TaskID),
-			d.Timeout(schema.TimeoutUpdate),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for volume with ID [%s] to return sync status of [%s]: %s", osVolumeID, ecloudservice.TaskStatusComplete, err)
-		}
-	}
-
-	if d.HasChange("volume_iops") {
-		osVolumeID := d.Get("volume_id").(string)
-		tflog.Info(ctx, "Updating volume", map[string]interface{}{
-			"volume_id": osVolumeID,
-		})
-		task, err := service.PatchVolume(osVolumeID, ecloudservice.PatchVolumeRequest{
-			IOPS: d.Get("volume_iops").(int),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "volume",
+			ResourceID: osVolumeID,
+			TaskID:     task.TaskID,
+			Sync:       VolumeSyncFunc(service, osVolumeID),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
 		})
-		if err != nil {
-			return diag.Errorf("Error updating volume with ID [%s]: %s", osVolumeID, err)
+		if diags.HasError() {
+			return diags
 		}
+	}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.TaskStatusComplete.String(),
-			TaskStatusRefreshFunc(ctx, service, task.TaskID),
-			d.Timeout(schema.TimeoutUpdate),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for volume with ID [%s] to return sync status of [%s]: %s", osVolumeID, ecloudservice.TaskStatusComplete, err)
+	if d.HasChange("network_interface") {
+		oldInterfaces, newInterfaces := d.GetChange("network_interface")
+		diags := resourceInstanceUpdateNetworkInterfaces(ctx, service, d, oldInterfaces.([]interface{}), newInterfaces.([]interface{}), d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -718,14 +847,16 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 				return diag.Errorf("Error attaching volume: %s", err)
 			}
 
-			_, err = waitForResourceState(
-				ctx,
-				ecloudservice.TaskStatusComplete.String(),
-				TaskStatusRefreshFunc(ctx, service, taskID),
-				d.Timeout(schema.TimeoutUpdate),
-			)
-			if err != nil {
-				return diag.Errorf("Error waiting for volume with ID [%s] to return sync status of [%s]: %s", volumeID, ecloudservice.TaskStatusComplete, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "attach",
+				Resource:   "volume",
+				ResourceID: volumeID,
+				TaskID:     taskID,
+				Sync:       VolumeSyncFunc(service, volumeID),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 
@@ -749,14 +880,16 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 				return diag.Errorf("Error detaching volume: %s", err)
 			}
 
-			_, err = waitForResourceState(
-				ctx,
-				ecloudservice.TaskStatusComplete.String(),
-				TaskStatusRefreshFunc(ctx, service, taskID),
-				d.Timeout(schema.TimeoutUpdate),
-			)
-			if err != nil {
-				return diag.Errorf("Error waiting for volume with ID [%s] to return sync status of [%s]: %s", volumeID, ecloudservice.TaskStatusComplete, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "detach",
+				Resource:   "volume",
+				ResourceID: volumeID,
+				TaskID:     taskID,
+				Sync:       VolumeSyncFunc(service, volumeID),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
@@ -777,14 +910,16 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			return diag.Errorf("Error migrating instance: %s", err)
 		}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.TaskStatusComplete.String(),
-			TaskStatusRefreshFunc(ctx, service, taskID),
-			d.Timeout(schema.TimeoutUpdate),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskID, ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "migrate",
+			Resource:   "instance",
+			ResourceID: d.Id(),
+			TaskID:     taskID,
+			Sync:       InstanceSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -805,14 +940,16 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			return diag.Errorf("Error migrating instance: %s", err)
 		}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.TaskStatusComplete.String(),
-			TaskStatusRefreshFunc(ctx, service, taskID),
-			d.Timeout(schema.TimeoutUpdate),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskID, ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "migrate",
+			Resource:   "instance",
+			ResourceID: d.Id(),
+			TaskID:     taskID,
+			Sync:       InstanceSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -829,14 +966,16 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 				return diag.Errorf("Error encrypting instance [%s]: %s", d.Id(), err)
 			}
 
-			_, err = waitForResourceState(
-				ctx,
-				ecloudservice.TaskStatusComplete.String(),
-				TaskStatusRefreshFunc(ctx, service, taskID),
-				d.Timeout(schema.TimeoutUpdate),
-			)
-			if err != nil {
-				return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskID, ecloudservice.TaskStatusComplete, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "encrypt",
+				Resource:   "instance",
+				ResourceID: d.Id(),
+				TaskID:     taskID,
+				Sync:       InstanceSyncFunc(service, d.Id()),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		} else {
 			taskID, err := service.DecryptInstance(d.Id())
@@ -844,18 +983,28 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 				return diag.Errorf("Error decrypting instance [%s]: %s", d.Id(), err)
 			}
 
-			_, err = waitForResourceState(
-				ctx,
-				ecloudservice.TaskStatusComplete.String(),
-				TaskStatusRefreshFunc(ctx, service, taskID),
-				d.Timeout(schema.TimeoutUpdate),
-			)
-			if err != nil {
-				return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskID, ecloudservice.TaskStatusComplete, err)
+			diags := waitForResourceOperation(ctx, service, resourceOperation{
+				Operation:  "decrypt",
+				Resource:   "instance",
+				ResourceID: d.Id(),
+				TaskID:     taskID,
+				Sync:       InstanceSyncFunc(service, d.Id()),
+				Timeout:    d.Timeout(schema.TimeoutUpdate),
+			})
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
 
+	// the power state has already been applied where the instance was restarted to resize
+	if d.HasChange("power_state") && !restart {
+		diags := resourceInstanceSetPowerState(ctx, service, d, d.Get("power_state").(string), d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	return resourceInstanceRead(ctx, d, meta)
 }
 
@@ -882,14 +1031,16 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta in
 			}
 		}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.TaskStatusComplete.String(),
-			TaskStatusRefreshFunc(ctx, service, taskID),
-			d.Timeout(schema.TimeoutDelete),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for floating ip with ID [%s] to be unassigned: %s", d.Id(), err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "unassign",
+			Resource:   "floating IP",
+			ResourceID: fip,
+			TaskID:     taskID,
+			Sync:       FloatingIPSyncFunc(service, fip),
+			Timeout:    d.Timeout(schema.TimeoutDelete),
+		})
+		if diags.HasError() {
+			return diags
 		}
 
 		tflog.Info(ctx, "Removing floating IP", map[string]interface{}{
@@ -908,14 +1059,16 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta in
 			}
 		}
 
-		_, err = waitForResourceState(
-			ctx,
-			ecloudservice.TaskStatusComplete.String(),
-			TaskStatusRefreshFunc(ctx, service, taskID),
-			d.Timeout(schema.TimeoutDelete),
-		)
-		if err != nil {
-			return diag.Errorf("Error waiting for floating ip with ID [%s] to be removed: %s", d.Id(), err)
+		diags = waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "delete",
+			Resource:   "floating IP",
+			ResourceID: fip,
+			TaskID:     taskID,
+			Sync:       FloatingIPSyncFunc(service, fip),
+			Timeout:    d.Timeout(schema.TimeoutDelete),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -924,53 +1077,626 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta in
 	})
 	err := service.DeleteInstance(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing instance with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing instance with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	_, err = waitForResourceState(
-		ctx,
-		"Deleted",
-		InstanceSyncStatusRefreshFunc(service, d.Id()),
-		d.Timeout(schema.TimeoutDelete),
-	)
-	if err != nil {
-		return diag.Errorf("Error waiting for instance with ID [%s] to be deleted: %s", d.Id(), err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "instance",
+		ResourceID: d.Id(),
+		Sync:       InstanceSyncFunc(service, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
-// InstanceSyncStatusRefreshFunc returns a function with StateRefreshFunc signature for use
-// with StateChangeConf
-func InstanceSyncStatusRefreshFunc(service ecloudservice.ECloudService, instanceID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+// InstanceSyncFunc returns a ResourceSyncFunc retrieving the sync state of the instance with given ID
+func InstanceSyncFunc(service ecloudservice.ECloudService, instanceID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
 		instance, err := service.GetInstance(instanceID)
 		if err != nil {
 			if _, ok := err.(*ecloudservice.InstanceNotFoundError); ok {
-				return instance, "Deleted", nil
+				return nil, nil
 			}
+			return nil, err
+		}
+
+		return &instance.Sync, nil
+	}
+}
+
+const (
+	instancePowerStateOnline  = "online"
+	instancePowerStateOffline = "offline"
+)
+
+// instancePowerState returns the power_state of an instance with given online status
+func instancePowerState(online bool) string {
+	if online {
+		return instancePowerStateOnline
+	}
+	return instancePowerStateOffline
+}
+
+// instancePowerOffReservedTimeout is the part of the timeout reserved for powering off an instance
+// which fails to shut down within shutdown_timeout, limited to half the timeout
+const instancePowerOffReservedTimeout = 2 * time.Minute
+
+// resourceInstanceSetPowerState powers the instance on or off, waiting for the power task to
+// complete. Instances are shut down gracefully unless graceful_shutdown is false. Where
+// force_power_off is set, an instance which fails to shut down within shutdown_timeout is
+// powered off instead
+func resourceInstanceSetPowerState(ctx context.Context, service ecloudservice.ECloudService, d *schema.ResourceData, powerState string, timeout time.Duration) diag.Diagnostics {
+	powerTask := func(operation string, powerFunc func(instanceID string) (string, error), timeout time.Duration) diag.Diagnostics {
+		tflog.Info(ctx, "Updating instance power state", map[string]interface{}{
+			"id":        d.Id(),
+			"operation": operation,
+		})
+		taskID, err := powerFunc(d.Id())
+		if err != nil {
+			return diag.Errorf("Error attempting to %s instance with ID [%s]: %s", operation, d.Id(), err)
+		}
+
+		return waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  operation,
+			Resource:   "instance",
+			ResourceID: d.Id(),
+			TaskID:     taskID,
+			Sync:       InstanceSyncFunc(service, d.Id()),
+			Timeout:    timeout,
+		})
+	}
+
+	if powerState == instancePowerStateOnline {
+		return powerTask("power on", service.PowerOnInstance, timeout)
+	}
+
+	if !d.Get("graceful_shutdown").(bool) {
+		return powerTask("power off", service.PowerOffInstance, timeout)
+	}
+
+	if !d.Get("force_power_off").(bool) {
+		return powerTask("shut down", service.PowerShutdownInstance, timeout)
+	}
+
+	// The power off fallback has the time remaining once shutdown_timeout has elapsed
+	deadline := time.Now().Add(timeout)
+
+	// shutdown_timeout is validated by the schema. It's capped so that part of the timeout is
+	// left for the power off fallback
+	shutdownTimeout, _ := time.ParseDuration(d.Get("shutdown_timeout").(string))
+	if reserved := min(instancePowerOffReservedTimeout, timeout/2); shutdownTimeout > timeout-reserved {
+		tflog.Warn(ctx, "Limiting shutdown_timeout to leave time to power off instance", map[string]interface{}{
+			"id":               d.Id(),
+			"shutdown_timeout": shutdownTimeout.String(),
+			"limit":            (timeout - reserved).String(),
+		})
+		shutdownTimeout = timeout - reserved
+	}
+
+	diags := powerTask("shut down", service.PowerShutdownInstance, shutdownTimeout)
+	if !diags.HasError() {
+		return nil
+	}
+
+	tflog.Warn(ctx, "Instance failed to shut down gracefully, powering off", map[string]interface{}{
+		"id":    d.Id(),
+		"error": diags[0].Summary,
+	})
+	return powerTask("power off", service.PowerOffInstance, timeoutRemaining(deadline))
+}
+
+const (
+	// instanceResizeStrategyHot resizes instances while they're running
+	instanceResizeStrategyHot = "hot"
+	// instanceResizeStrategyShutdownRestart shuts instances down while they're resized
+	instanceResizeStrategyShutdownRestart = "shutdown_restart"
+)
+
+// resourceInstanceResize applies patchReq to the instance where hasChange is set, and any change to
+// the capacity of its OS volume
+func resourceInstanceResize(ctx context.Context, service ecloudservice.ECloudService, d *schema.ResourceData, hasChange bool, patchReq ecloudservice.PatchInstanceRequest, deadline time.Time) diag.Diagnostics {
+	if hasChange {
+		tflog.Debug(ctx, fmt.Sprintf("Created PatchInstanceRequest: %+v", patchReq))
+
+		tflog.Info(ctx, "Updating instance", map[string]interface{}{
+			"id": d.Id(),
+		})
+		err := service.PatchInstance(d.Id(), patchReq)
+		if err != nil {
+			return diag.Errorf("Error updating instance with ID [%s]: %s", d.Id(), err)
+		}
+
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "instance",
+			ResourceID: d.Id(),
+			Sync:       InstanceSyncFunc(service, d.Id()),
+			Timeout:    timeoutRemaining(deadline),
+		})
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// manage volume capacity
+	if d.HasChange("volume_capacity") {
+		osVolumeID := d.Get("volume_id").(string)
+		tflog.Info(ctx, "Updating volume", map[string]interface{}{
+			"volume_id": osVolumeID,
+		})
+		task, err := service.PatchVolume(osVolumeID, ecloudservice.PatchVolumeRequest{
+			Capacity: d.Get("volume_capacity").(int),
+		})
+		if err != nil {
+			return diag.Errorf("Error updating volume with ID [%s]: %s", osVolumeID, err)
+		}
+
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "volume",
+			ResourceID: osVolumeID,
+			TaskID:     task.TaskID,
+			Sync:       VolumeSyncFunc(service, osVolumeID),
+			Timeout:    timeoutRemaining(deadline),
+		})
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return nil
+}
+
+// resourceInstanceStartAfterResize powers the instance on once resized, waiting for it to report
+// as online
+func resourceInstanceStartAfterResize(ctx context.Context, service ecloudservice.ECloudService, d *schema.ResourceData, timeout time.Duration) diag.Diagnostics {
+	deadline := time.Now().Add(timeout)
+
+	tflog.Info(ctx, "Powering on resized instance", map[string]interface{}{
+		"id": d.Id(),
+	})
+	diags := resourceInstanceSetPowerState(ctx, service, d, instancePowerStateOnline, timeout)
+	if diags.HasError() {
+		return diags
+	}
+
+	_, err := waitForResourceState(ctx, service, poll.Wait{
+		Target:  instancePowerStateOnline,
+		Refresh: poll.RefreshFunc(InstancePowerStateRefreshFunc(service, d.Id())),
+		Timeout: timeoutRemaining(deadline),
+	})
+	if err != nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error waiting for instance with ID [%s] to come online after resize", d.Id()),
+				Detail:   fmt.Sprintf("%s\n\nThe instance was resized while shut down and has been powered on, but hasn't reported as online. Check the instance console before applying again.", err),
+			},
+		}
+	}
+
+	return nil
+}
+
+// instanceStartAfterFailedResizeTimeout is the time to wait for an instance to power back on
+// following a failed resize. It's separate from the update timeout, as the resize may have
+// failed by running out of it
+const instanceStartAfterFailedResizeTimeout = 5 * time.Minute
+
+// resourceInstanceStartAfterFailedResize makes a best effort to power the instance back on where
+// resizing has failed after it was shut down, so that it isn't left offline unnoticed where
+// power_state isn't configured
+func resourceInstanceStartAfterFailedResize(ctx context.Context, service ecloudservice.ECloudService, d *schema.ResourceData) diag.Diagnostics {
+	tflog.Warn(ctx, "Resize failed, powering instance back on", map[string]interface{}{
+		"id": d.Id(),
+	})
+
+	// ctx is done where the resize timed out, though the instance should still be powered on
+	diags := resourceInstanceSetPowerState(context.WithoutCancel(ctx), service, d, instancePowerStateOnline, instanceStartAfterFailedResizeTimeout)
+	if !diags.HasError() {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error powering on instance with ID [%s] following failed resize", d.Id()),
+			Detail:   fmt.Sprintf("%s\n\nThe instance was shut down to resize, and may have been left offline. Check its power state before applying again.", diags[0].Summary),
+		},
+	}
+}
+
+// InstancePowerStateRefreshFunc returns a function with StateRefreshFunc signature for use with
+// waitForResourceState, reporting the power_state of the instance with given ID
+func InstancePowerStateRefreshFunc(service ecloudservice.ECloudService, instanceID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		instance, err := service.GetInstance(instanceID)
+		if err != nil {
 			return nil, "", err
 		}
 
-		if instance.Sync.Status == ecloudservice.SyncStatusFailed {
-			return nil, "", fmt.Errorf("Failed to create/update instance - review logs")
+		if instance.Online == nil {
+			return instance, "", nil
+		}
+
+		return instance, instancePowerState(*instance.Online), nil
+	}
+}
+
+// resourceInstanceCustomizeDiffNetworkInterfaces rejects network_interface configuration which
+// can't be applied, and replaces the instance where its first network interface changes
+func resourceInstanceCustomizeDiffNetworkInterfaces(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// NICs can only be given a fixed IP address when created along with the instance. ip_address
+	// is computed, so is only known to be configured where it's set on a new network interface,
+	// or changed on an existing one
+	oldInterfaces, newInterfaces := d.GetChange("network_interface")
+	for i := 1; i < len(newInterfaces.([]interface{})); i++ {
+		key := fmt.Sprintf("network_interface.%d.ip_address", i)
+		if !d.NewValueKnown(key) || d.Get(key).(string) == "" {
+			continue
+		}
+
+		if i >= len(oldInterfaces.([]interface{})) || d.HasChange(key) {
+			return fmt.Errorf("network_interface.%d.ip_address cannot be set, fixed IP addresses are only supported by the first network interface", i)
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	// network_id and ip_address replace the instance, unless they're being removed in favour of
+	// an equivalent first network interface, so that the instance can be migrated to
+	// network_interface blocks in place. They're then cleared from state
+	for _, key := range []string{"network_id", "ip_address"} {
+		if !d.HasChange(key) {
+			continue
+		}
+
+		oldValue, newValue := d.GetChange(key)
+		interfaceKey := "network_interface.0." + key
+		if d.NewValueKnown(key) && newValue.(string) == "" && d.NewValueKnown(interfaceKey) && d.Get(interfaceKey).(string) == oldValue.(string) {
+			continue
+		}
+
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range []string{"network_interface.0.network_id", "network_interface.0.ip_address"} {
+		if !d.HasChange(key) || !d.NewValueKnown(key) {
+			continue
+		}
+
+		// ip_address is computed where not configured
+		if oldValue, newValue := d.GetChange(key); oldValue.(string) == "" || newValue.(string) == "" {
+			continue
+		}
+
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceInstanceReadNetworkInterfaces sets network_interface from the NICs of the instance.
+// NICs are matched to network interfaces by ID, so that their order is kept and NICs attached
+// using the ecloud_nic resource are ignored. Network interfaces whose NIC no longer exists are
+// cleared, so that the NIC is recreated. Where network_interface isn't yet held in state, such
+// as for instances created using network_id or imported, only the primary NIC is reported, as
+// any others may be managed by ecloud_nic resources
+func resourceInstanceReadNetworkInterfaces(service ecloudservice.ECloudService, d *schema.ResourceData, nics []ecloudservice.NIC) error {
+	fips, err := service.GetInstanceFloatingIPs(d.Id(), connection.APIRequestParameters{})
+	if err != nil {
+		return err
+	}
+
+	nicFloatingIPs := make(map[string]string)
+	for _, fip := range fips {
+		nicFloatingIPs[fip.ResourceID] = fip.ID
+	}
+
+	flatten := func(nic ecloudservice.NIC) map[string]interface{} {
+		return map[string]interface{}{
+			"id":             nic.ID,
+			"network_id":     nic.NetworkID,
+			"ip_address":     nic.IPAddress,
+			"floating_ip_id": nicFloatingIPs[nic.ID],
+			"mac_address":    nic.MACAddress,
+		}
+	}
+
+	nicsByID := make(map[string]ecloudservice.NIC)
+	for _, nic := range nics {
+		nicsByID[nic.ID] = nic
+	}
+
+	networkInterfaces := d.Get("network_interface").([]interface{})
+	if len(networkInterfaces) < 1 {
+		if len(nics) < 1 {
+			return d.Set("network_interface", nil)
+		}
+
+		primary, ok := nicsByID[d.Get("nic_id").(string)]
+		if !ok {
+			if len(nics) > 1 {
+				return fmt.Errorf("unable to identify primary NIC [%s] among %d NICs", d.Get("nic_id").(string), len(nics))
+			}
+			primary = nics[0]
+		}
+
+		return d.Set("network_interface", []interface{}{flatten(primary)})
+	}
+
+	for i, rawInterface := range networkInterfaces {
+		nic, ok := nicsByID[rawInterface.(map[string]interface{})["id"].(string)]
+		if !ok {
+			networkInterfaces[i] = flatten(ecloudservice.NIC{})
+			continue
+		}
+
+		networkInterfaces[i] = flatten(nic)
+	}
+
+	return d.Set("network_interface", networkInterfaces)
+}
+
+// resourceInstanceUpdateNetworkInterfaces converges the NICs of the instance from
+// oldInterfaces to newInterfaces, in order. NICs are created for added network interfaces and
+// deleted for removed ones, or replaced where their network changes. Floating IPs are unassigned
+// before any are assigned, so that they can be moved between network interfaces. The first
+// network interface is created along with the instance, so is only ever updated. Changes are
+// saved to state as they're made, so that they're retained on failure
+func resourceInstanceUpdateNetworkInterfaces(ctx context.Context, service ecloudservice.ECloudService, d *schema.ResourceData, oldInterfaces []interface{}, newInterfaces []interface{}, timeout time.Duration) diag.Diagnostics {
+	current := make([]interface{}, len(oldInterfaces))
+	for i, rawInterface := range oldInterfaces {
+		networkInterface := make(map[string]interface{})
+		for k, v := range rawInterface.(map[string]interface{}) {
+			networkInterface[k] = v
+		}
+		current[i] = networkInterface
+	}
+
+	if len(current) < 1 {
+		nics, err := service.GetInstanceNICs(d.Id(), connection.APIRequestParameters{})
+		if err != nil {
+			return diag.Errorf("Failed to retrieve instance nics: %s", err)
+		}
+
+		if len(nics) != 1 {
+			return diag.Errorf("Unexpected number of instance nics (%d), expected 1", len(nics))
+		}
+
+		current = append(current, map[string]interface{}{
+			"id":             nics[0].ID,
+			"network_id":     nics[0].NetworkID,
+			"ip_address":     nics[0].IPAddress,
+			"floating_ip_id": "",
+			"mac_address":    nics[0].MACAddress,
+		})
+	}
+
+	// replaced returns whether the NIC of the network interface at index i is to be deleted,
+	// either as it's no longer configured, or as it's moving to another network. The first
+	// network interface is never replaced, as the instance is replaced instead
+	replaced := func(i int) bool {
+		if i < 1 {
+			return false
+		}
+		if i >= len(newInterfaces) {
+			return true
+		}
+		return current[i].(map[string]interface{})["network_id"] != newInterfaces[i].(map[string]interface{})["network_id"]
+	}
+
+	for i, rawInterface := range current {
+		networkInterface := rawInterface.(map[string]interface{})
+		floatingIPID := networkInterface["floating_ip_id"].(string)
+		if floatingIPID == "" {
+			continue
+		}
+
+		if !replaced(i) && floatingIPID == newInterfaces[i].(map[string]interface{})["floating_ip_id"] {
+			continue
+		}
+
+		diags := resourceInstanceUnassignFloatingIP(ctx, service, floatingIPID, timeout)
+		if diags.HasError() {
+			return diags
+		}
+
+		networkInterface["floating_ip_id"] = ""
+		d.Set("network_interface", current)
+	}
+
+	// NICs can't be moved between networks, so are replaced. Network interfaces whose NIC no
+	// longer exists have no ID, so are recreated
+	for i := len(current) - 1; i >= 0; i-- {
+		networkInterface := current[i].(map[string]interface{})
+		if !replaced(i) {
+			continue
+		}
+
+		if nicID := networkInterface["id"].(string); nicID != "" {
+			diags := resourceInstanceDeleteNIC(ctx, service, nicID, timeout)
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		if i >= len(newInterfaces) {
+			current = current[:i]
+		} else {
+			networkInterface["id"] = ""
+		}
+		d.Set("network_interface", current)
+	}
+
+	for i, rawInterface := range newInterfaces {
+		networkInterface := rawInterface.(map[string]interface{})
+		if i < len(current) && current[i].(map[string]interface{})["id"].(string) != "" {
+			continue
+		}
+
+		networkID := networkInterface["network_id"].(string)
+		createReq := ecloudservice.CreateNICRequest{
+			InstanceID: d.Id(),
+			NetworkID:  networkID,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Created CreateNICRequest: %+v", createReq))
+
+		tflog.Info(ctx, "Creating instance NIC", map[string]interface{}{
+			"instance_id": d.Id(),
+			"network_id":  networkID,
+		})
+		taskRef, err := service.CreateNIC(createReq)
+		if err != nil {
+			return diag.Errorf("Error creating NIC for network_interface.%d: %s", i, err)
+		}
+
+		created := map[string]interface{}{
+			"id":             taskRef.ResourceID,
+			"network_id":     networkID,
+			"ip_address":     "",
+			"floating_ip_id": "",
+			"mac_address":    "",
+		}
+		if i < len(current) {
+			current[i] = created
+		} else {
+			current = append(current, created)
+		}
+		d.Set("network_interface", current)
+
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "create",
+			Resource:   "NIC",
+			ResourceID: taskRef.ResourceID,
+			TaskID:     taskRef.TaskID,
+			Timeout:    timeout,
+		})
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	for i, rawInterface := range newInterfaces {
+		networkInterface := current[i].(map[string]interface{})
+		floatingIPID := rawInterface.(map[string]interface{})["floating_ip_id"].(string)
+		if floatingIPID == "" || floatingIPID == networkInterface["floating_ip_id"] {
+			continue
+		}
+
+		diags := resourceInstanceAssignFloatingIP(ctx, service, floatingIPID, networkInterface["id"].(string), timeout)
+		if diags.HasError() {
+			return diags
 		}
 
-		return instance, instance.Sync.Status.String(), nil
+		networkInterface["floating_ip_id"] = floatingIPID
+		d.Set("network_interface", current)
+	}
+
+	d.Set("network_interface", current)
+
+	return nil
+}
+
+// resourceInstanceDeleteNIC deletes the NIC with given ID
+func resourceInstanceDeleteNIC(ctx context.Context, service ecloudservice.ECloudService, nicID string, timeout time.Duration) diag.Diagnostics {
+	tflog.Info(ctx, "Removing instance NIC", map[string]interface{}{
+		"nic_id": nicID,
+	})
+	taskID, err := service.DeleteNIC(nicID)
+	if err != nil {
+		switch err.(type) {
+		case *ecloudservice.NICNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing NIC with ID [%s]: %s", nicID, err)
+		}
 	}
+
+	return waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "NIC",
+		ResourceID: nicID,
+		TaskID:     taskID,
+		Timeout:    timeout,
+	})
 }
 
-// waitForResourceState is a wrapper for the resource.StateChangeConf helper in order to reduce duplication
-func waitForResourceState(ctx context.Context, targetState string, refreshFunc resource.StateRefreshFunc, timeout time.Duration) (interface{}, error) {
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{targetState},
-		Refresh:    refreshFunc,
+// resourceInstanceAssignFloatingIP assigns the floating IP with given ID to the NIC with given ID
+func resourceInstanceAssignFloatingIP(ctx context.Context, service ecloudservice.ECloudService, floatingIPID string, nicID string, timeout time.Duration) diag.Diagnostics {
+	unlock, err := lock.LockResource(ctx, floatingIPID)
+	if err != nil {
+		return diag.Errorf("Error locking floating IP: %s", err)
+	}
+	defer unlock()
+
+	tflog.Debug(ctx, "Assigning floating IP", map[string]interface{}{
+		"fip_id": floatingIPID,
+		"nic_id": nicID,
+	})
+	taskID, err := service.AssignFloatingIP(floatingIPID, ecloudservice.AssignFloatingIPRequest{
+		ResourceID: nicID,
+	})
+	if err != nil {
+		return diag.Errorf("Error assigning floating IP with ID [%s]: %s", floatingIPID, err)
+	}
+
+	return waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "assign",
+		Resource:   "floating IP",
+		ResourceID: floatingIPID,
+		TaskID:     taskID,
+		Sync:       FloatingIPSyncFunc(service, floatingIPID),
 		Timeout:    timeout,
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+	})
+}
+
+// resourceInstanceUnassignFloatingIP unassigns the floating IP with given ID, without deleting it
+// as it may be managed by another resource
+func resourceInstanceUnassignFloatingIP(ctx context.Context, service ecloudservice.ECloudService, floatingIPID string, timeout time.Duration) diag.Diagnostics {
+	unlock, err := lock.LockResource(ctx, floatingIPID)
+	if err != nil {
+		return diag.Errorf("Error locking floating IP: %s", err)
 	}
+	defer unlock()
 
-	return stateConf.WaitForStateContext(ctx)
+	tflog.Debug(ctx, "Unassigning floating IP", map[string]interface{}{
+		"fip_id": floatingIPID,
+	})
+	taskID, err := service.UnassignFloatingIP(floatingIPID)
+	if err != nil {
+		switch err.(type) {
+		case *ecloudservice.FloatingIPNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error unassigning floating ip with ID [%s]: %s", floatingIPID, err)
+		}
+	}
+
+	return waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "unassign",
+		Resource:   "floating IP",
+		ResourceID: floatingIPID,
+		TaskID:     taskID,
+		Sync:       FloatingIPSyncFunc(service, floatingIPID),
+		Timeout:    timeout,
+	})
 }
 
 // expands the vcpu block configuration, returns sockets and cores per socket
@@ -991,3 +1717,163 @@ func expandVCPUConfig(l []interface{}) (sockets int, coresPerSocket int) {
 
 	return
 }
+
+// resourceInstanceCustomizeDiffVCPU keeps vcpu_cores and the vcpu block consistent, as both are
+// held in state. Where the vcpu block is changed, vcpu_cores is zeroed to remove it from the API
+// call, as supplying vcpu_cores along with the sockets/cores_per_socket options is not allowed by
+// the API. Where vcpu_cores is changed, the topology held in the vcpu block will change
+func resourceInstanceCustomizeDiffVCPU(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := d.GetOk("vcpu"); ok && d.HasChange("vcpu") {
+		return d.SetNew("vcpu_cores", 0)
+	}
+
+	if d.HasChange("vcpu_cores") && (!d.NewValueKnown("vcpu_cores") || d.Get("vcpu_cores").(int) > 0) {
+		return d.SetNewComputed("vcpu")
+	}
+
+	return nil
+}
+
+// resourceInstanceCustomizeDiffCapacity rejects vCPU, RAM and volume configuration which the API
+// would refuse part way through an apply. vCPU and RAM are checked against the host spec of the
+// host group, and volume IOPS against the IOPS tiers of the availability zone
+func resourceInstanceCustomizeDiffCapacity(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	service := meta.(ecloudservice.ECloudService)
+
+	vcpuCores, vcpuKnown := resourceInstanceDiffVCPUCores(d)
+	if vcpuKnown && vcpuCores < 1 {
+		return fmt.Errorf("vcpu.sockets and vcpu.cores_per_socket must both be at least 1")
+	}
+
+	if d.Id() != "" && d.HasChange("volume_capacity") && d.NewValueKnown("volume_capacity") {
+		oldCapacity, newCapacity := d.GetChange("volume_capacity")
+		if newCapacity.(int) < oldCapacity.(int) {
+			return fmt.Errorf("volume_capacity cannot be reduced from %dGiB to %dGiB, volumes can only be expanded", oldCapacity.(int), newCapacity.(int))
+		}
+	}
+
+	// The availability zone is only retrieved where required, as it requires both the network
+	// and its router
+	availabilityZoneID := func() (string, error) {
+		networkID, ok := d.GetOk("network_id")
+		if !ok || !d.NewValueKnown("network_id") {
+			return "", nil
+		}
+
+		network, err := service.GetNetwork(networkID.(string))
+		if err != nil {
+			return "", fmt.Errorf("Error retrieving network with ID [%s]: %s", networkID.(string), err)
+		}
+
+		router, err := service.GetRouter(network.RouterID)
+		if err != nil {
+			return "", fmt.Errorf("Error retrieving router with ID [%s]: %s", network.RouterID, err)
+		}
+
+		return router.AvailabilityZoneID, nil
+	}
+
+	hostGroupID, ok := d.GetOk("host_group_id")
+	if ok && d.NewValueKnown("host_group_id") && d.HasChanges("host_group_id", "ram_capacity", "vcpu", "vcpu_cores") {
+		tflog.Debug(ctx, "Validating instance capacity against host spec", map[string]interface{}{
+			"host_group_id": hostGroupID,
+		})
+		hostGroup, err := service.GetHostGroup(hostGroupID.(string))
+		if err != nil {
+			return fmt.Errorf("Error retrieving host group with ID [%s]: %s", hostGroupID.(string), err)
+		}
+
+		spec, err := service.GetHostSpec(hostGroup.HostSpecID)
+		if err != nil {
+			return fmt.Errorf("Error retrieving host spec with ID [%s]: %s", hostGroup.HostSpecID, err)
+		}
+
+		hostCores := spec.CPUSockets * spec.CPUCores
+		if vcpuKnown && vcpuCores > hostCores {
+			return fmt.Errorf("Instance requires %d vCPU cores, but hosts in host group [%s] with host spec [%s] have %d cores (%d sockets of %d cores)",
+				vcpuCores, hostGroup.ID, spec.Name, hostCores, spec.CPUSockets, spec.CPUCores)
+		}
+
+		// Host spec RAM capacity is in GiB, whereas instance RAM capacity is in MiB
+		ramCapacity := d.Get("ram_capacity").(int)
+		if d.NewValueKnown("ram_capacity") && ramCapacity > spec.RAMCapacity*1024 {
+			return fmt.Errorf("ram_capacity of %dMiB exceeds the %dGiB RAM of hosts in host group [%s] with host spec [%s]",
+				ramCapacity, spec.RAMCapacity, hostGroup.ID, spec.Name)
+		}
+	}
+
+	resourceTierID, ok := d.GetOk("resource_tier_id")
+	if ok && d.NewValueKnown("resource_tier_id") && d.HasChange("resource_tier_id") {
+		tier, err := service.GetResourceTier(resourceTierID.(string))
+		if err != nil {
+			switch err.(type) {
+			case *ecloudservice.ResourceTierNotFoundError:
+				return fmt.Errorf("Resource tier with ID [%s] does not exist", resourceTierID.(string))
+			default:
+				return fmt.Errorf("Error retrieving resource tier with ID [%s]: %s", resourceTierID.(string), err)
+			}
+		}
+
+		azID, err := availabilityZoneID()
+		if err != nil {
+			return err
+		}
+		if azID != "" && tier.AvailabilityZoneID != azID {
+			return fmt.Errorf("Resource tier [%s] is in availability zone [%s], but the instance network is in availability zone [%s]",
+				tier.ID, tier.AvailabilityZoneID, azID)
+		}
+	}
+
+	volumeIOPS, ok := d.GetOk("volume_iops")
+	if ok && d.NewValueKnown("volume_iops") && d.HasChange("volume_iops") {
+		azID, err := availabilityZoneID()
+		if err != nil {
+			return err
+		}
+
+		var tiers []ecloudservice.IOPSTier
+		if azID != "" {
+			tiers, err = service.GetAvailabilityZoneIOPSTiers(azID, connection.APIRequestParameters{})
+		} else {
+			tiers, err = service.GetIOPSTiers(connection.APIRequestParameters{})
+		}
+		if err != nil {
+			return fmt.Errorf("Error retrieving IOPS tiers: %s", err)
+		}
+
+		var levels []string
+		for _, tier := range tiers {
+			if tier.Level == volumeIOPS.(int) {
+				return nil
+			}
+			levels = append(levels, strconv.Itoa(tier.Level))
+		}
+
+		return fmt.Errorf("volume_iops of %d is not an available IOPS tier, expected one of [%s]", volumeIOPS.(int), strings.Join(levels, ", "))
+	}
+
+	return nil
+}
+
+// resourceInstanceDiffVCPUCores returns the total vCPU cores planned for the instance, from either
+// the vcpu block or vcpu_cores. known is false where these aren't yet known
+func resourceInstanceDiffVCPUCores(d *schema.ResourceDiff) (cores int, known bool) {
+	if !d.NewValueKnown("vcpu_cores") {
+		return 0, false
+	}
+
+	if vcpuCores := d.Get("vcpu_cores").(int); vcpuCores > 0 {
+		return vcpuCores, true
+	}
+
+	if _, ok := d.GetOk("vcpu"); ok {
+		if !d.NewValueKnown("vcpu.0.sockets") || !d.NewValueKnown("vcpu.0.cores_per_socket") {
+			return 0, false
+		}
+
+		sockets, coresPerSocket := expandVCPUConfig(d.Get("vcpu").([]interface{}))
+		return sockets * coresPerSocket, true
+	}
+
+	return 0, true
+}
@@ -0,0 +1,469 @@
+package ecloud
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	instancePasswordLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	instancePasswordUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	instancePasswordNumericChars = "0123456789"
+	// instancePasswordSpecialChars excludes characters with special meaning within quoted strings
+	// in sh, cmd and PowerShell, as the password is embedded within the rotation script
+	instancePasswordSpecialChars = "!#*+-.=?@_~"
+	// instancePasswordUnsafeChars cannot be used within override_special
+	instancePasswordUnsafeChars = "'\"`$\\%^&|<> \t\r\n"
+)
+
+// instanceCredentialRotationPolicyKeys are the attributes defining the password policy, changes
+// to which cause the password to be rotated
+var instanceCredentialRotationPolicyKeys = []string{
+	"length",
+	"lower",
+	"upper",
+	"numeric",
+	"special",
+	"min_lower",
+	"min_upper",
+	"min_numeric",
+	"min_special",
+	"override_special",
+}
+
+func resourceInstanceCredentialRotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInstanceCredentialRotationCreate,
+		ReadContext:   resourceInstanceCredentialRotationRead,
+		UpdateContext: resourceInstanceCredentialRotationUpdate,
+		DeleteContext: resourceInstanceCredentialRotationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9._-]+$`), "must only contain alphanumeric characters, periods, underscores and hyphens"),
+			},
+			"initial_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      24,
+				ValidateFunc: validation.IntBetween(12, 127),
+			},
+			"lower": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"upper": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"numeric": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"special": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"min_lower": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_upper": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_numeric": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_special": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"override_special": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstancePasswordSpecialChars,
+			},
+			"rotation_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"rotated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceInstanceCredentialRotationCustomizeDiffPolicy,
+			resourceInstanceCredentialRotationCustomizeDiffRotate,
+		),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceInstanceCredentialRotationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	instanceID := d.Get("instance_id").(string)
+	username := d.Get("username").(string)
+
+	currentPassword := d.Get("initial_password").(string)
+	if currentPassword == "" {
+		params := connection.APIRequestParameters{}
+		params.WithFilter(*connection.NewAPIRequestFiltering("username", connection.EQOperator, []string{username}))
+
+		credentials, err := service.GetInstanceCredentials(instanceID, params)
+		if err != nil {
+			return diag.Errorf("Error retrieving credentials for instance with ID [%s]: %s", instanceID, err)
+		}
+
+		if len(credentials) < 1 {
+			return diag.Errorf("No credentials found for user [%s] on instance with ID [%s], initial_password must be provided", username, instanceID)
+		}
+
+		currentPassword = credentials[0].Password
+	}
+
+	taskID, diags := resourceInstanceCredentialRotationRotate(ctx, service, d, currentPassword, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(taskID)
+
+	return diags
+}
+
+func resourceInstanceCredentialRotationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	tflog.Info(ctx, "Retrieving instance", map[string]interface{}{
+		"id": d.Get("instance_id").(string),
+	})
+	_, err := service.GetInstance(d.Get("instance_id").(string))
+	if err != nil {
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceInstanceCredentialRotationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	// A rotation is planned by CustomizeDiff marking the password as unknown
+	if !d.HasChange("password") {
+		return resourceInstanceCredentialRotationRead(ctx, d, meta)
+	}
+
+	// Retain the previous password in state should the rotation fail, as it will be required to
+	// authenticate the next attempt
+	d.Partial(true)
+
+	currentPassword, _ := d.GetChange("password")
+	_, diags := resourceInstanceCredentialRotationRotate(ctx, service, d, currentPassword.(string), d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceInstanceCredentialRotationRead(ctx, d, meta)...)
+}
+
+// resourceInstanceCredentialRotationDelete removes the resource from state only, leaving the
+// current password in place on the instance
+func resourceInstanceCredentialRotationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Warn(ctx, "Removing credential rotation from state, the current password remains set on the instance", map[string]interface{}{
+		"instance_id": d.Get("instance_id").(string),
+		"username":    d.Get("username").(string),
+	})
+
+	return nil
+}
+
+// resourceInstanceCredentialRotationRotate generates a new password and sets it on the instance
+// using a guest script authenticated with currentPassword, recording the new password in state
+// once the script has completed
+func resourceInstanceCredentialRotationRotate(ctx context.Context, service ecloudservice.ECloudService, d *schema.ResourceData, currentPassword string, timeout time.Duration) (string, diag.Diagnostics) {
+	instanceID := d.Get("instance_id").(string)
+	username := d.Get("username").(string)
+
+	instance, err := service.GetInstance(instanceID)
+	if err != nil {
+		return "", diag.Errorf("Error retrieving instance with ID [%s]: %s", instanceID, err)
+	}
+
+	password, err := generateInstancePassword(expandInstancePasswordPolicy(d.Get))
+	if err != nil {
+		return "", diag.Errorf("Error generating password for user [%s] on instance with ID [%s]: %s", username, instanceID, err)
+	}
+
+	executeReq := ecloudservice.ExecuteInstanceScriptRequest{
+		Script:   instanceSetPasswordScript(instance.Platform, username, password),
+		Username: username,
+		Password: currentPassword,
+	}
+
+	tflog.Info(ctx, "Rotating instance credential", map[string]interface{}{
+		"instance_id": instanceID,
+		"username":    username,
+	})
+	taskID, err := service.ExecuteInstanceScript(instanceID, executeReq)
+	if err != nil {
+		return "", diag.Errorf("Error rotating credential for user [%s] on instance with ID [%s]: %s", username, instanceID, err)
+	}
+
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "rotate",
+		Resource:   "credential on instance",
+		ResourceID: instanceID,
+		TaskID:     taskID,
+		Sync:       InstanceSyncFunc(service, instanceID),
+		Timeout:    timeout,
+	})
+	if diags.HasError() {
+		return "", diags
+	}
+
+	d.Partial(false)
+	d.Set("password", password)
+	d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+
+	return taskID, diags
+}
+
+// resourceInstanceCredentialRotationCustomizeDiffPolicy ensures that a password can be generated
+// which satisfies the configured policy
+func resourceInstanceCredentialRotationCustomizeDiffPolicy(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return expandInstancePasswordPolicy(d.Get).validate()
+}
+
+// resourceInstanceCredentialRotationCustomizeDiffRotate plans a rotation of the password where the
+// keepers or password policy have changed, or the rotation_days window has elapsed
+func resourceInstanceCredentialRotationCustomizeDiffRotate(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	rotate := d.HasChange("keepers") || d.HasChanges(instanceCredentialRotationPolicyKeys...)
+
+	if rotationDays := d.Get("rotation_days").(int); rotationDays > 0 {
+		rotatedAt, err := time.Parse(time.RFC3339, d.Get("rotated_at").(string))
+		if err != nil || !time.Now().Before(rotatedAt.AddDate(0, 0, rotationDays)) {
+			rotate = true
+		}
+	}
+
+	if !rotate {
+		return nil
+	}
+
+	if err := d.SetNewComputed("password"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("rotated_at")
+}
+
+// instanceSetPasswordScript returns a guest script setting the password for username, for the
+// given instance platform
+func instanceSetPasswordScript(platform string, username string, password string) string {
+	if strings.EqualFold(platform, "windows") {
+		return fmt.Sprintf("net user %s \"%s\"", username, password)
+	}
+
+	return fmt.Sprintf("chpasswd <<'EOF'\n%s:%s\nEOF", username, password)
+}
+
+// instancePasswordPolicy defines the character classes and length of generated passwords
+type instancePasswordPolicy struct {
+	Length       int
+	Lower        bool
+	Upper        bool
+	Numeric      bool
+	Special      bool
+	MinLower     int
+	MinUpper     int
+	MinNumeric   int
+	MinSpecial   int
+	SpecialChars string
+}
+
+// expandInstancePasswordPolicy returns the password policy using get, which will typically be the
+// Get method of schema.ResourceData or schema.ResourceDiff
+func expandInstancePasswordPolicy(get func(key string) interface{}) instancePasswordPolicy {
+	policy := instancePasswordPolicy{
+		Length:       get("length").(int),
+		Lower:        get("lower").(bool),
+		Upper:        get("upper").(bool),
+		Numeric:      get("numeric").(bool),
+		Special:      get("special").(bool),
+		MinLower:     get("min_lower").(int),
+		MinUpper:     get("min_upper").(int),
+		MinNumeric:   get("min_numeric").(int),
+		MinSpecial:   get("min_special").(int),
+		SpecialChars: get("override_special").(string),
+	}
+	if policy.SpecialChars == "" {
+		policy.SpecialChars = instancePasswordSpecialChars
+	}
+
+	return policy
+}
+
+// classes returns the character sets enabled by the policy, along with the minimum number of
+// characters required from each
+func (p instancePasswordPolicy) classes() ([]string, []int) {
+	var chars []string
+	var mins []int
+
+	add := func(enabled bool, set string, n int) {
+		if enabled {
+			chars = append(chars, set)
+			mins = append(mins, n)
+		}
+	}
+	add(p.Lower, instancePasswordLowerChars, p.MinLower)
+	add(p.Upper, instancePasswordUpperChars, p.MinUpper)
+	add(p.Numeric, instancePasswordNumericChars, p.MinNumeric)
+	add(p.Special, p.SpecialChars, p.MinSpecial)
+
+	return chars, mins
+}
+
+func (p instancePasswordPolicy) validate() error {
+	chars, mins := p.classes()
+	if len(chars) < 1 {
+		return errors.New("at least one of lower, upper, numeric or special must be enabled")
+	}
+
+	total := 0
+	for _, n := range mins {
+		total += n
+	}
+	if total > p.Length {
+		return fmt.Errorf("length [%d] is less than the sum of the minimum character counts [%d]", p.Length, total)
+	}
+
+	return nil
+}
+
+// generateInstancePassword returns a random password satisfying policy, using crypto/rand
+func generateInstancePassword(policy instancePasswordPolicy) (string, error) {
+	if err := policy.validate(); err != nil {
+		return "", err
+	}
+
+	chars, mins := policy.classes()
+
+	password := make([]byte, 0, policy.Length)
+	for i, set := range chars {
+		for j := 0; j < mins[i]; j++ {
+			c, err := randomInstancePasswordChar(set)
+			if err != nil {
+				return "", err
+			}
+			password = append(password, c)
+		}
+	}
+
+	all := strings.Join(chars, "")
+	for len(password) < policy.Length {
+		c, err := randomInstancePasswordChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// Shuffle so that required characters aren't grouped at the start of the password
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomInstancePasswordChar(set string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, err
+	}
+
+	return set[i.Int64()], nil
+}
+
+func validateInstancePasswordSpecialChars(v interface{}, k string) (ws []string, es []error) {
+	if strings.ContainsAny(v.(string), instancePasswordUnsafeChars) {
+		es = append(es, fmt.Errorf("%s must not contain whitespace or any of the characters %q", k, strings.TrimSpace(instancePasswordUnsafeChars)))
+	}
+
+	return
+}
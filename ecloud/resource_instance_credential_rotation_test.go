@@ -0,0 +1,267 @@
+package ecloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitInstanceCredentialRotation_create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("Linux_UsesAPICredential", func(t *testing.T) {
+		service := newFakeECloudService()
+		instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+		service.AddInstanceCredential(instanceID, "root", "initialpassword")
+
+		d := schema.TestResourceDataRaw(t, resourceInstanceCredentialRotation().Schema, map[string]interface{}{
+			"instance_id": instanceID,
+			"username":    "root",
+		})
+
+		diags := resourceInstanceCredentialRotationCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.NotEmpty(t, d.Id())
+
+		password := d.Get("password").(string)
+		assert.Len(t, password, 24)
+		assert.NotEmpty(t, d.Get("rotated_at"))
+
+		reqs := service.ScriptRequests(instanceID)
+		assert.Len(t, reqs, 1)
+		assert.Equal(t, "root", reqs[0].Username)
+		assert.Equal(t, "initialpassword", reqs[0].Password)
+		assert.Equal(t, "chpasswd <<'EOF'\nroot:"+password+"\nEOF", reqs[0].Script)
+	})
+
+	t.Run("Windows_UsesInitialPassword", func(t *testing.T) {
+		service := newFakeECloudService()
+		instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+		service.instances[instanceID].value.Platform = "Windows"
+
+		d := schema.TestResourceDataRaw(t, resourceInstanceCredentialRotation().Schema, map[string]interface{}{
+			"instance_id":      instanceID,
+			"username":         "Administrator",
+			"initial_password": "initialpassword",
+		})
+
+		diags := resourceInstanceCredentialRotationCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		reqs := service.ScriptRequests(instanceID)
+		assert.Len(t, reqs, 1)
+		assert.Equal(t, "initialpassword", reqs[0].Password)
+		assert.Equal(t, "net user Administrator \""+d.Get("password").(string)+"\"", reqs[0].Script)
+	})
+
+	t.Run("NoCredential_ReturnsError", func(t *testing.T) {
+		service := newFakeECloudService()
+		instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+
+		d := schema.TestResourceDataRaw(t, resourceInstanceCredentialRotation().Schema, map[string]interface{}{
+			"instance_id": instanceID,
+			"username":    "root",
+		})
+
+		diags := resourceInstanceCredentialRotationCreate(ctx, d, service)
+		assert.True(t, diags.HasError())
+		assert.Empty(t, d.Id())
+		assert.Empty(t, service.ScriptRequests(instanceID))
+	})
+
+	t.Run("FailedScript_ReturnsError", func(t *testing.T) {
+		service := newFakeECloudService()
+		service.FailScripts = true
+		instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+		service.AddInstanceCredential(instanceID, "root", "initialpassword")
+
+		d := schema.TestResourceDataRaw(t, resourceInstanceCredentialRotation().Schema, map[string]interface{}{
+			"instance_id": instanceID,
+			"username":    "root",
+		})
+
+		diags := resourceInstanceCredentialRotationCreate(ctx, d, service)
+		assert.True(t, diags.HasError())
+		assert.Empty(t, d.Id())
+	})
+}
+
+func TestUnitInstanceCredentialRotation_rotate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := resourceInstanceCredentialRotation()
+
+	// setup creates a rotation resource, returning the service and resulting state
+	setup := func(t *testing.T, raw map[string]interface{}) (*fakeECloudService, string, *terraform.InstanceState) {
+		service := newFakeECloudService()
+		instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+		service.AddInstanceCredential(instanceID, "root", "initialpassword")
+
+		raw["instance_id"] = instanceID
+		raw["username"] = "root"
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		diags := resourceInstanceCredentialRotationCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		return service, instanceID, d.State()
+	}
+
+	// apply applies given configuration to state
+	apply := func(t *testing.T, service *fakeECloudService, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), service)
+		assert.Nil(t, err)
+
+		return r.Apply(ctx, state, diff, service)
+	}
+
+	t.Run("Unchanged_DoesNotRotate", func(t *testing.T) {
+		service, instanceID, state := setup(t, map[string]interface{}{"rotation_days": 30})
+
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"instance_id":   instanceID,
+			"username":      "root",
+			"rotation_days": 30,
+		}), service)
+		assert.Nil(t, err)
+		assert.True(t, diff == nil || diff.Empty())
+	})
+
+	t.Run("KeepersChanged_Rotates", func(t *testing.T) {
+		service, instanceID, state := setup(t, map[string]interface{}{
+			"keepers": map[string]interface{}{"version": "1"},
+		})
+		previous := state.Attributes["password"]
+
+		newState, diags := apply(t, service, state, map[string]interface{}{
+			"instance_id": instanceID,
+			"username":    "root",
+			"keepers":     map[string]interface{}{"version": "2"},
+		})
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.NotEqual(t, previous, newState.Attributes["password"])
+		assert.Len(t, newState.Attributes["password"], 24)
+
+		reqs := service.ScriptRequests(instanceID)
+		assert.Len(t, reqs, 2)
+		assert.Equal(t, previous, reqs[1].Password)
+		assert.Contains(t, reqs[1].Script, newState.Attributes["password"])
+	})
+
+	t.Run("PolicyChanged_Rotates", func(t *testing.T) {
+		service, instanceID, state := setup(t, map[string]interface{}{})
+
+		newState, diags := apply(t, service, state, map[string]interface{}{
+			"instance_id": instanceID,
+			"username":    "root",
+			"length":      40,
+		})
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Len(t, newState.Attributes["password"], 40)
+		assert.Len(t, service.ScriptRequests(instanceID), 2)
+	})
+
+	t.Run("RotationWindowElapsed_Rotates", func(t *testing.T) {
+		service, instanceID, state := setup(t, map[string]interface{}{"rotation_days": 30})
+		previous := state.Attributes["password"]
+		state.Attributes["rotated_at"] = time.Now().AddDate(0, 0, -31).UTC().Format(time.RFC3339)
+
+		newState, diags := apply(t, service, state, map[string]interface{}{
+			"instance_id":   instanceID,
+			"username":      "root",
+			"rotation_days": 30,
+		})
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.NotEqual(t, previous, newState.Attributes["password"])
+		assert.NotEqual(t, state.Attributes["rotated_at"], newState.Attributes["rotated_at"])
+	})
+
+	t.Run("FailedScript_RetainsPreviousPassword", func(t *testing.T) {
+		service, instanceID, state := setup(t, map[string]interface{}{
+			"keepers": map[string]interface{}{"version": "1"},
+		})
+		previous := state.Attributes["password"]
+		service.FailScripts = true
+
+		newState, diags := apply(t, service, state, map[string]interface{}{
+			"instance_id": instanceID,
+			"username":    "root",
+			"keepers":     map[string]interface{}{"version": "2"},
+		})
+		assert.True(t, diags.HasError())
+		assert.Equal(t, previous, newState.Attributes["password"])
+		assert.Equal(t, "1", newState.Attributes["keepers.version"])
+	})
+}
+
+func TestGenerateInstancePassword(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		policy := instancePasswordPolicy{
+			Length:       24,
+			Lower:        true,
+			Upper:        true,
+			Numeric:      true,
+			Special:      true,
+			MinLower:     1,
+			MinUpper:     1,
+			MinNumeric:   1,
+			MinSpecial:   1,
+			SpecialChars: instancePasswordSpecialChars,
+		}
+
+		for i := 0; i < 50; i++ {
+			password, err := generateInstancePassword(policy)
+			assert.Nil(t, err)
+			assert.Len(t, password, 24)
+			assert.True(t, strings.ContainsAny(password, instancePasswordLowerChars))
+			assert.True(t, strings.ContainsAny(password, instancePasswordUpperChars))
+			assert.True(t, strings.ContainsAny(password, instancePasswordNumericChars))
+			assert.True(t, strings.ContainsAny(password, instancePasswordSpecialChars))
+			assert.False(t, strings.ContainsAny(password, instancePasswordUnsafeChars))
+		}
+	})
+
+	t.Run("MinimumCounts", func(t *testing.T) {
+		password, err := generateInstancePassword(instancePasswordPolicy{
+			Length:     12,
+			Numeric:    true,
+			Upper:      true,
+			MinNumeric: 6,
+			MinUpper:   6,
+		})
+		assert.Nil(t, err)
+
+		numeric := 0
+		for _, c := range password {
+			if strings.ContainsRune(instancePasswordNumericChars, c) {
+				numeric++
+			}
+		}
+		assert.Equal(t, 6, numeric)
+	})
+
+	t.Run("NoCharacterClasses_ReturnsError", func(t *testing.T) {
+		_, err := generateInstancePassword(instancePasswordPolicy{Length: 12})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("MinimumsExceedLength_ReturnsError", func(t *testing.T) {
+		_, err := generateInstancePassword(instancePasswordPolicy{
+			Length:   12,
+			Lower:    true,
+			Upper:    true,
+			MinLower: 7,
+			MinUpper: 7,
+		})
+		assert.NotNil(t, err)
+	})
+}
@@ -3,13 +3,11 @@ package ecloud
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"time"
 
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,6 +15,7 @@ func resourceInstanceScript() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceInstanceScriptCreate,
 		ReadContext:   resourceInstanceScriptRead,
+		UpdateContext: resourceInstanceScriptUpdate,
 		DeleteContext: resourceInstanceScriptDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -44,10 +43,36 @@ func resourceInstanceScript() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"run_on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"fail_on_nonzero_exit": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"task_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -55,41 +80,116 @@ func resourceInstanceScript() *schema.Resource {
 func resourceInstanceScriptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(ecloudservice.ECloudService)
 
-	createReq := ecloudservice.ExecuteInstanceScriptRequest{
-		Script:   d.Get("script").(string),
-		Username: d.Get("username").(string),
-		Password: d.Get("password").(string),
+	taskID, diags := resourceInstanceScriptExecute(ctx, service, d, d.Get("script").(string), d.Timeout(schema.TimeoutCreate))
+	if taskID != "" {
+		d.SetId(taskID)
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Created CreateInstanceScriptRequest: %+v", createReq))
 
-	tflog.Info(ctx, "Executing InstanceScript")
-	taskID, err := service.ExecuteInstanceScript(d.Get("instance_id").(string), createReq)
+	return diags
+}
+
+func resourceInstanceScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
+
+	tflog.Info(ctx, "Retrieving instance", map[string]interface{}{
+		"id": d.Get("instance_id").(string),
+	})
+	_, err := service.GetInstance(d.Get("instance_id").(string))
 	if err != nil {
-		return diag.Errorf("Error executing InstanceScript %s", err)
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
 	}
 
-	d.SetId(fmt.Sprintf("%d", rand.Int()))
+	return nil
+}
+
+// resourceInstanceScriptUpdate stores changes to run_on_destroy and fail_on_nonzero_exit, which
+// don't require the script to be run again
+func resourceInstanceScriptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceInstanceScriptRead(ctx, d, meta)
+}
+
+func resourceInstanceScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(ecloudservice.ECloudService)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+	script := d.Get("run_on_destroy").(string)
+	if script == "" {
+		return nil
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err := service.GetInstance(d.Get("instance_id").(string))
 	if err != nil {
-		return diag.Errorf("Error waiting for InstanceScript with ID [%s] to run: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			tflog.Debug(ctx, "Instance not found, skipping run_on_destroy script", map[string]interface{}{
+				"instance_id": d.Get("instance_id").(string),
+			})
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
 	}
 
-	return nil
+	_, diags := resourceInstanceScriptExecute(ctx, service, d, script, d.Timeout(schema.TimeoutDelete))
+	return diags
 }
 
-func resourceInstanceScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return nil
-}
+// resourceInstanceScriptExecute runs script on the instance, waiting for its task to complete and
+// recording the task in state. The API reports a script exiting with a non-zero code as a failed
+// task, which is reported as a warning rather than an error where fail_on_nonzero_exit is false
+func resourceInstanceScriptExecute(ctx context.Context, service ecloudservice.ECloudService, d *schema.ResourceData, script string, timeout time.Duration) (string, diag.Diagnostics) {
+	instanceID := d.Get("instance_id").(string)
 
-func resourceInstanceScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return nil
+	executeReq := ecloudservice.ExecuteInstanceScriptRequest{
+		Script:   script,
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+	}
+
+	tflog.Info(ctx, "Executing script on instance", map[string]interface{}{
+		"instance_id": instanceID,
+	})
+	taskID, err := service.ExecuteInstanceScript(instanceID, executeReq)
+	if err != nil {
+		return "", diag.Errorf("Error executing script on instance with ID [%s]: %s", instanceID, err)
+	}
+
+	d.Set("task_id", taskID)
+
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "run",
+		Resource:   "script on instance",
+		ResourceID: instanceID,
+		TaskID:     taskID,
+		Sync:       InstanceSyncFunc(service, instanceID),
+		Timeout:    timeout,
+	})
+
+	task, err := service.GetTask(taskID)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to retrieve script task", map[string]interface{}{
+			"task_id": taskID,
+			"error":   err.Error(),
+		})
+		return taskID, diags
+	}
+
+	d.Set("task_status", task.Status.String())
+
+	if !diags.HasError() || task.Status != ecloudservice.TaskStatusFailed || d.Get("fail_on_nonzero_exit").(bool) {
+		return taskID, diags
+	}
+
+	return taskID, diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Script on instance with ID [%s] failed", instanceID),
+			Detail:   fmt.Sprintf("Task [%s] has status [%s]. The failure has been ignored as fail_on_nonzero_exit is false.", task.ID, task.Status),
+		},
+	}
 }
@@ -1,11 +1,16 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccInstanceScript(t *testing.T) {
@@ -98,3 +103,104 @@ resource "ecloud_instance_script" "test-script" {
 }
 `, scriptContent)
 }
+
+func TestUnitInstanceScript_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+
+	d := schema.TestResourceDataRaw(t, resourceInstanceScript().Schema, map[string]interface{}{
+		"instance_id":    instanceID,
+		"username":       "root",
+		"password":       "password",
+		"script":         "hostname",
+		"run_on_destroy": "deregister",
+	})
+
+	diags := resourceInstanceScriptCreate(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, d.Id(), d.Get("task_id"))
+	assert.Equal(t, "complete", d.Get("task_status"))
+	assert.Equal(t, []string{"hostname"}, service.Scripts(instanceID))
+
+	diags = resourceInstanceScriptDelete(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, []string{"hostname", "deregister"}, service.Scripts(instanceID))
+}
+
+func TestUnitInstanceScript_failed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	create := func(t *testing.T, failOnNonZeroExit bool) (*schema.ResourceData, diag.Diagnostics) {
+		service := newFakeECloudService()
+		service.FailScripts = true
+		instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+
+		d := schema.TestResourceDataRaw(t, resourceInstanceScript().Schema, map[string]interface{}{
+			"instance_id":          instanceID,
+			"username":             "root",
+			"password":             "password",
+			"script":               "exit 1",
+			"fail_on_nonzero_exit": failOnNonZeroExit,
+		})
+
+		return d, resourceInstanceScriptCreate(ctx, d, service)
+	}
+
+	t.Run("FailOnNonZeroExit_ReturnsError", func(t *testing.T) {
+		d, diags := create(t, true)
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "failed", d.Get("task_status"))
+	})
+
+	t.Run("IgnoreNonZeroExit_ReturnsWarning", func(t *testing.T) {
+		d, diags := create(t, false)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.NotEmpty(t, d.Id())
+		assert.Equal(t, "failed", d.Get("task_status"))
+	})
+}
+
+func TestUnitInstanceScript_triggers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	config := func(triggers map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"instance_id": "i-abcdef12",
+			"username":    "root",
+			"password":    "password",
+			"script":      "hostname",
+			"triggers":    triggers,
+		})
+	}
+
+	state := &terraform.InstanceState{
+		ID: "task-abcdef12",
+		Attributes: map[string]string{
+			"id":                   "task-abcdef12",
+			"instance_id":          "i-abcdef12",
+			"username":             "root",
+			"password":             "password",
+			"script":               "hostname",
+			"fail_on_nonzero_exit": "true",
+			"triggers.%":           "1",
+			"triggers.version":     "1",
+		},
+	}
+
+	diff, err := resourceInstanceScript().Diff(ctx, state, config(map[string]interface{}{"version": "1"}), nil)
+	assert.Nil(t, err)
+	assert.True(t, diff.Empty())
+
+	diff, err = resourceInstanceScript().Diff(ctx, state, config(map[string]interface{}{"version": "2"}), nil)
+	assert.Nil(t, err)
+	assert.True(t, diff.RequiresNew())
+}
@@ -0,0 +1,271 @@
+package ecloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceInstanceV0 is the instance resource as at schema version 0, used only to decode state
+// for upgrade. It must not be changed as attributes are added to resourceInstance
+func resourceInstanceV0() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"image_data": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"user_script": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"vcpu_cores": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Deprecated:    "Use the vcpu block instead. To migrate, set vcpu.sockets to your current vcpu_cores value, and vcpu.cores_per_socket to 1.",
+				ConflictsWith: []string{"vcpu"},
+				AtLeastOneOf:  []string{"vcpu_cores", "vcpu"},
+			},
+			"vcpu": {
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				Optional:      true,
+				ConflictsWith: []string{"vcpu_cores"},
+				AtLeastOneOf:  []string{"vcpu_cores", "vcpu"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sockets": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtMost(10),
+						},
+						"cores_per_socket": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"ram_capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"volume_capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"volume_iops": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"backup_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"backup_gateway_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"backup_agent_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"monitoring_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"monitoring_gateway_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"network_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"nic_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"floating_ip_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"requires_floating_ip"},
+			},
+			"requires_floating_ip": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ForceNew:      true,
+				ConflictsWith: []string{"floating_ip_id"},
+			},
+			"data_volume_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Computed: true,
+				Set:      schema.HashString,
+			},
+			"ssh_keypair_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"host_group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"resource_tier_id"},
+			},
+			"resource_tier_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"host_group_id"},
+			},
+			"volume_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scope": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tag_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+// resourceInstanceStateUpgradeV0 populates the vcpu block in state using the deprecated vcpu_cores
+// attribute, using the current topology of the instance. vcpu_cores is retained, so that no changes
+// are planned whether the configuration continues to use vcpu_cores or is migrated to the vcpu block
+func resourceInstanceStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	// Attributes added since version 0 which only affect the provider's behaviour are set to their
+	// defaults, as they otherwise appear as changes in the plan
+	for k, v := range resourceInstance().Schema {
+		if _, ok := rawState[k]; !ok && v.Default != nil {
+			rawState[k] = v.Default
+		}
+	}
+
+	if vcpu, ok := rawState["vcpu"].([]interface{}); ok && len(vcpu) > 0 && vcpu[0] != nil {
+		return rawState, nil
+	}
+
+	instanceID, _ := rawState["id"].(string)
+	if instanceID == "" {
+		return rawState, nil
+	}
+
+	service := meta.(ecloudservice.ECloudService)
+
+	tflog.Info(ctx, "Upgrading instance state to vcpu block", map[string]interface{}{
+		"id": instanceID,
+	})
+	instance, err := service.GetInstance(instanceID)
+	if err != nil {
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			// Removed from state on the next read
+			return rawState, nil
+		default:
+			return nil, fmt.Errorf("Error retrieving instance with ID [%s]: %s", instanceID, err)
+		}
+	}
+
+	sockets, coresPerSocket := instance.VCPUSockets, instance.VCPUCoresPerSocket
+	if sockets < 1 {
+		sockets, coresPerSocket = instance.VCPUCores, 1
+	}
+
+	rawState["vcpu"] = []interface{}{
+		map[string]interface{}{
+			"sockets":          sockets,
+			"cores_per_socket": coresPerSocket,
+		},
+	}
+
+	return rawState, nil
+}
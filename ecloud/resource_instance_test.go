@@ -2,15 +2,24 @@ package ecloud
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"text/template"
+	"time"
 
+	"github.com/ans-group/sdk-go/pkg/connection"
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccInstance_basic(t *testing.T) {
@@ -348,3 +357,877 @@ resource "ecloud_instance" "test-instance-vcpu" {
 
 	return buf.String()
 }
+
+func TestUnitInstance_basic(t *testing.T) {
+	testUnitPreCheck(t)
+	t.Parallel()
+
+	service := newFakeECloudService()
+	resourceName := "ecloud_instance.test-instance"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testUnitProviderFactories(service),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitResourceInstanceConfig_basic(2048),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ram_capacity", "2048"),
+					resource.TestCheckResourceAttr(resourceName, "vcpu.0.sockets", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "volume_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "nic_id"),
+				),
+			},
+			{
+				Config: testUnitResourceInstanceConfig_basic(4096),
+				Check:  resource.TestCheckResourceAttr(resourceName, "ram_capacity", "4096"),
+			},
+		},
+	})
+}
+
+func TestUnitInstance_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	service.SyncSteps = 1
+
+	d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{
+		"vpc_id":          "vpc-abcdef12",
+		"network_id":      "net-abcdef12",
+		"name":            "test-instance",
+		"image_id":        "img-abcdef12",
+		"ram_capacity":    2048,
+		"volume_capacity": 40,
+		"vcpu": []interface{}{
+			map[string]interface{}{"sockets": 2, "cores_per_socket": 1},
+		},
+	})
+
+	diags := resourceInstanceCreate(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.NotEmpty(t, d.Id())
+	assert.NotEmpty(t, d.Get("volume_id"))
+	assert.NotEmpty(t, d.Get("nic_id"))
+	assert.Equal(t, 2, d.Get("vcpu.0.sockets"))
+
+	instance, err := service.GetInstance(d.Id())
+	assert.Nil(t, err)
+	assert.Equal(t, 2048, instance.RAMCapacity)
+
+	diags = resourceInstanceDelete(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+	_, err = service.GetInstance(d.Id())
+	assert.IsType(t, &ecloudservice.InstanceNotFoundError{}, err)
+}
+
+func TestUnitInstance_syncFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+	service.Fail(instanceID)
+
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "instance",
+		ResourceID: instanceID,
+		Sync:       InstanceSyncFunc(service, instanceID),
+		Timeout:    time.Minute,
+	})
+	assert.True(t, diags.HasError())
+	assert.Equal(t, fmt.Sprintf("Error waiting to create instance with ID [%s]", instanceID), diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, fmt.Sprintf("The instance [%s] has sync status [failed]", instanceID))
+}
+
+func testUnitResourceInstanceConfig_basic(ramCapacity int) string {
+	return fmt.Sprintf(`
+resource "ecloud_instance" "test-instance" {
+	vpc_id = "vpc-abcdef12"
+	network_id = "net-abcdef12"
+	name = "test-instance"
+	image_id = "img-abcdef12"
+	volume_capacity = 40
+	ram_capacity = %d
+
+	vcpu {
+		sockets = 1
+		cores_per_socket = 1
+	}
+}
+`, ramCapacity)
+}
+
+func TestUnitInstance_importByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12", Name: "web"})
+	service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12", Name: "db"})
+	service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12", Name: "db"})
+	service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-other", Name: "web"})
+	importer := resourceInstance().Importer.StateContext
+
+	t.Run("ID", func(t *testing.T) {
+		d := resourceInstance().TestResourceData()
+		d.SetId(instanceID)
+
+		_, err := importer(ctx, d, service)
+		assert.Nil(t, err)
+		assert.Equal(t, instanceID, d.Id())
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		d := resourceInstance().TestResourceData()
+		d.SetId("vpc-abcdef12:name=web")
+
+		_, err := importer(ctx, d, service)
+		assert.Nil(t, err)
+		assert.Equal(t, instanceID, d.Id())
+	})
+
+	t.Run("NameNotFound_ReturnsError", func(t *testing.T) {
+		d := resourceInstance().TestResourceData()
+		d.SetId("vpc-abcdef12:name=cache")
+
+		_, err := importer(ctx, d, service)
+		assert.EqualError(t, err, "No instance found with name [cache] in VPC [vpc-abcdef12]")
+	})
+
+	t.Run("NameAmbiguous_ReturnsError", func(t *testing.T) {
+		d := resourceInstance().TestResourceData()
+		d.SetId("vpc-abcdef12:name=db")
+
+		_, err := importer(ctx, d, service)
+		assert.ErrorContains(t, err, "Ambiguous name [db] in VPC [vpc-abcdef12]")
+	})
+}
+
+func TestUnitInstance_stateUpgradeV0(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{
+		VPCID:              "vpc-abcdef12",
+		VCPUSockets:        2,
+		VCPUCoresPerSocket: 4,
+	})
+
+	t.Run("VCPUCores", func(t *testing.T) {
+		state, err := resourceInstanceStateUpgradeV0(ctx, map[string]interface{}{
+			"id":         instanceID,
+			"vcpu_cores": 8,
+		}, service)
+		assert.Nil(t, err)
+		assert.Equal(t, 8, state["vcpu_cores"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"sockets": 2, "cores_per_socket": 4},
+		}, state["vcpu"])
+	})
+
+	t.Run("VCPUBlock_Unchanged", func(t *testing.T) {
+		vcpu := []interface{}{
+			map[string]interface{}{"sockets": 1, "cores_per_socket": 8},
+		}
+		state, err := resourceInstanceStateUpgradeV0(ctx, map[string]interface{}{
+			"id":   instanceID,
+			"vcpu": vcpu,
+		}, service)
+		assert.Nil(t, err)
+		assert.Equal(t, vcpu, state["vcpu"])
+	})
+
+	t.Run("NotFound_Unchanged", func(t *testing.T) {
+		state, err := resourceInstanceStateUpgradeV0(ctx, map[string]interface{}{
+			"id":         "i-missing",
+			"vcpu_cores": 8,
+		}, service)
+		assert.Nil(t, err)
+		assert.Equal(t, 8, state["vcpu_cores"])
+		assert.Nil(t, state["vcpu"])
+	})
+}
+
+func TestUnitInstance_stateUpgradeV0_plan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := resourceInstance()
+
+	config := func(vcpu map[string]interface{}) map[string]interface{} {
+		raw := map[string]interface{}{
+			"vpc_id":          "vpc-abcdef12",
+			"network_id":      "net-abcdef12",
+			"image_id":        "img-abcdef12",
+			"ram_capacity":    2048,
+			"volume_capacity": 40,
+		}
+		for k, v := range vcpu {
+			raw[k] = v
+		}
+		return raw
+	}
+	vcpuCoresConfig := config(map[string]interface{}{"vcpu_cores": 2})
+
+	// upgradedState creates an instance using vcpu_cores, then returns its state as held by schema
+	// version 0 once upgraded and refreshed
+	upgradedState := func(t *testing.T, service *fakeECloudService) *terraform.InstanceState {
+		d := schema.TestResourceDataRaw(t, r.Schema, vcpuCoresConfig)
+		diags := resourceInstanceCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		// vcpu wasn't held in state where vcpu_cores was used at schema version 0
+		state := d.State()
+		for k := range state.Attributes {
+			if strings.HasPrefix(k, "vcpu.") {
+				delete(state.Attributes, k)
+			}
+		}
+
+		v0, err := state.AttrsAsObjectValue(resourceInstanceV0().CoreConfigSchema().ImpliedType())
+		assert.Nil(t, err)
+		v0JSON, err := ctyjson.Marshal(v0, v0.Type())
+		assert.Nil(t, err)
+
+		var rawState map[string]interface{}
+		assert.Nil(t, json.Unmarshal(v0JSON, &rawState))
+		assert.Nil(t, rawState["vcpu"])
+
+		rawState, err = r.StateUpgraders[0].Upgrade(ctx, rawState, service)
+		assert.Nil(t, err)
+
+		v1JSON, err := json.Marshal(rawState)
+		assert.Nil(t, err)
+		v1, err := ctyjson.Unmarshal(v1JSON, r.CoreConfigSchema().ImpliedType())
+		assert.Nil(t, err)
+		upgraded, err := r.ShimInstanceStateFromValue(v1)
+		assert.Nil(t, err)
+
+		// State is refreshed following upgrade, prior to planning
+		refreshed, diags := r.RefreshWithoutUpgrade(ctx, upgraded, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		return refreshed
+	}
+
+	t.Run("VCPUCoresUnchanged_EmptyPlan", func(t *testing.T) {
+		service := newFakeECloudService()
+		state := upgradedState(t, service)
+
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(vcpuCoresConfig), service)
+		assert.Nil(t, err)
+		assert.True(t, diff == nil || diff.Empty(), "unexpected diff: %v", diff)
+	})
+
+	t.Run("MigratedToVCPUBlock_EmptyPlan", func(t *testing.T) {
+		service := newFakeECloudService()
+		state := upgradedState(t, service)
+
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config(map[string]interface{}{
+			"vcpu": []interface{}{
+				map[string]interface{}{"sockets": 2, "cores_per_socket": 1},
+			},
+		})), service)
+		assert.Nil(t, err)
+		assert.True(t, diff == nil || diff.Empty(), "unexpected diff: %v", diff)
+	})
+
+	t.Run("VCPUBlockChanged_PatchesTopology", func(t *testing.T) {
+		service := &resizeRecordingService{fakeECloudService: newFakeECloudService()}
+		state := upgradedState(t, service.fakeECloudService)
+
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config(map[string]interface{}{
+			"vcpu": []interface{}{
+				map[string]interface{}{"sockets": 2, "cores_per_socket": 2},
+			},
+		})), service)
+		assert.Nil(t, err)
+
+		newState, diags := r.Apply(ctx, state, diff, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, []string{"patch instance"}, service.operations)
+		assert.Equal(t, "2", newState.Attributes["vcpu.0.cores_per_socket"])
+
+		instance, _ := service.GetInstance(state.ID)
+		assert.Equal(t, 2, instance.VCPUSockets)
+		assert.Equal(t, 2, instance.VCPUCoresPerSocket)
+	})
+}
+
+func TestUnitInstance_customizeDiffCapacity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	hostGroupID := service.AddHostGroup(ecloudservice.HostSpec{Name: "small", CPUSockets: 2, CPUCores: 4, RAMCapacity: 64})
+	routerID, _ := service.CreateRouter(ecloudservice.CreateRouterRequest{VPCID: "vpc-abcdef12", AvailabilityZoneID: "az-abcdef12"})
+	networkID, _ := service.CreateNetwork(ecloudservice.CreateNetworkRequest{RouterID: routerID})
+	resourceTierID := service.AddResourceTier("az-other")
+	service.AddIOPSTiers("az-abcdef12", 300, 600)
+
+	diff := func(config map[string]interface{}, state *terraform.InstanceState) error {
+		raw := map[string]interface{}{
+			"vpc_id":          "vpc-abcdef12",
+			"network_id":      networkID,
+			"image_id":        "img-abcdef12",
+			"ram_capacity":    2048,
+			"volume_capacity": 40,
+			"vcpu": []interface{}{
+				map[string]interface{}{"sockets": 2, "cores_per_socket": 2},
+			},
+		}
+		for k, v := range config {
+			raw[k] = v
+		}
+
+		_, err := resourceInstance().Diff(ctx, state, terraform.NewResourceConfigRaw(raw), service)
+		return err
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		err := diff(map[string]interface{}{"host_group_id": hostGroupID, "volume_iops": 600}, nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("VCPUExceedsHostSpec_ReturnsError", func(t *testing.T) {
+		err := diff(map[string]interface{}{
+			"host_group_id": hostGroupID,
+			"vcpu": []interface{}{
+				map[string]interface{}{"sockets": 4, "cores_per_socket": 4},
+			},
+		}, nil)
+		assert.EqualError(t, err, fmt.Sprintf("Instance requires 16 vCPU cores, but hosts in host group [%s] with host spec [small] have 8 cores (2 sockets of 4 cores)", hostGroupID))
+	})
+
+	t.Run("RAMExceedsHostSpec_ReturnsError", func(t *testing.T) {
+		err := diff(map[string]interface{}{"host_group_id": hostGroupID, "ram_capacity": 131072}, nil)
+		assert.EqualError(t, err, fmt.Sprintf("ram_capacity of 131072MiB exceeds the 64GiB RAM of hosts in host group [%s] with host spec [small]", hostGroupID))
+	})
+
+	t.Run("ResourceTierInOtherAvailabilityZone_ReturnsError", func(t *testing.T) {
+		err := diff(map[string]interface{}{"resource_tier_id": resourceTierID}, nil)
+		assert.EqualError(t, err, fmt.Sprintf("Resource tier [%s] is in availability zone [az-other], but the instance network is in availability zone [az-abcdef12]", resourceTierID))
+	})
+
+	t.Run("InvalidIOPS_ReturnsError", func(t *testing.T) {
+		err := diff(map[string]interface{}{"volume_iops": 1200}, nil)
+		assert.EqualError(t, err, "volume_iops of 1200 is not an available IOPS tier, expected one of [300, 600]")
+	})
+
+	t.Run("VolumeCapacityReduced_ReturnsError", func(t *testing.T) {
+		state := &terraform.InstanceState{
+			ID: "i-abcdef12",
+			Attributes: map[string]string{
+				"id":                      "i-abcdef12",
+				"vpc_id":                  "vpc-abcdef12",
+				"network_id":              networkID,
+				"image_id":                "img-abcdef12",
+				"ram_capacity":            "2048",
+				"volume_capacity":         "80",
+				"vcpu.#":                  "1",
+				"vcpu.0.sockets":          "2",
+				"vcpu.0.cores_per_socket": "2",
+			},
+		}
+
+		err := diff(nil, state)
+		assert.EqualError(t, err, "volume_capacity cannot be reduced from 80GiB to 40GiB, volumes can only be expanded")
+	})
+}
+
+func TestUnitInstance_powerState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newInstance := func(t *testing.T, service *fakeECloudService, config map[string]interface{}) *schema.ResourceData {
+		raw := map[string]interface{}{
+			"vpc_id":          "vpc-abcdef12",
+			"network_id":      "net-abcdef12",
+			"image_id":        "img-abcdef12",
+			"ram_capacity":    2048,
+			"volume_capacity": 40,
+			"vcpu": []interface{}{
+				map[string]interface{}{"sockets": 1, "cores_per_socket": 1},
+			},
+		}
+		for k, v := range config {
+			raw[k] = v
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceInstance().Schema, raw)
+		diags := resourceInstanceCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		return d
+	}
+
+	t.Run("CreateOffline", func(t *testing.T) {
+		service := newFakeECloudService()
+		d := newInstance(t, service, map[string]interface{}{"power_state": "offline"})
+
+		instance, err := service.GetInstance(d.Id())
+		assert.Nil(t, err)
+		assert.False(t, *instance.Online)
+		assert.Equal(t, "offline", d.Get("power_state"))
+	})
+
+	t.Run("ReadDetectsDrift", func(t *testing.T) {
+		service := newFakeECloudService()
+		d := newInstance(t, service, nil)
+		assert.Equal(t, "online", d.Get("power_state"))
+
+		service.PowerOffInstance(d.Id())
+
+		diags := resourceInstanceRead(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, "offline", d.Get("power_state"))
+	})
+
+	t.Run("PowerOn", func(t *testing.T) {
+		service := newFakeECloudService()
+		d := newInstance(t, service, map[string]interface{}{"power_state": "offline"})
+
+		diags := resourceInstanceSetPowerState(ctx, service, d, "online", time.Minute)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		instance, _ := service.GetInstance(d.Id())
+		assert.True(t, *instance.Online)
+	})
+
+	t.Run("ShutdownFailed_ReturnsError", func(t *testing.T) {
+		service := newFakeECloudService()
+		service.FailShutdown = true
+		d := newInstance(t, service, nil)
+
+		diags := resourceInstanceSetPowerState(ctx, service, d, "offline", time.Minute)
+		assert.True(t, diags.HasError())
+		assert.Equal(t, fmt.Sprintf("Error waiting to shut down instance with ID [%s]", d.Id()), diags[0].Summary)
+
+		instance, _ := service.GetInstance(d.Id())
+		assert.True(t, *instance.Online)
+	})
+
+	t.Run("ShutdownFailed_ForcePowerOff", func(t *testing.T) {
+		service := newFakeECloudService()
+		service.FailShutdown = true
+		d := newInstance(t, service, map[string]interface{}{"force_power_off": true, "shutdown_timeout": "30s"})
+
+		diags := resourceInstanceSetPowerState(ctx, service, d, "offline", time.Minute)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		instance, _ := service.GetInstance(d.Id())
+		assert.False(t, *instance.Online)
+	})
+
+	t.Run("ShutdownTimedOut_ForcePowerOff", func(t *testing.T) {
+		service := newFakeECloudService()
+		service.HangShutdown = true
+		d := newInstance(t, service, map[string]interface{}{"force_power_off": true, "shutdown_timeout": "50ms"})
+
+		start := time.Now()
+		diags := resourceInstanceSetPowerState(ctx, service, d, "offline", time.Minute)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Less(t, time.Since(start), 10*time.Second)
+
+		instance, _ := service.GetInstance(d.Id())
+		assert.False(t, *instance.Online)
+	})
+
+	t.Run("ShutdownTimeoutExceedsTimeout_ForcePowerOff", func(t *testing.T) {
+		service := newFakeECloudService()
+		service.HangShutdown = true
+		d := newInstance(t, service, map[string]interface{}{"force_power_off": true, "shutdown_timeout": "1h"})
+
+		diags := resourceInstanceSetPowerState(ctx, service, d, "offline", 200*time.Millisecond)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		instance, _ := service.GetInstance(d.Id())
+		assert.False(t, *instance.Online)
+	})
+
+	t.Run("ShutdownTimedOut_ReturnsError", func(t *testing.T) {
+		service := newFakeECloudService()
+		service.HangShutdown = true
+		d := newInstance(t, service, nil)
+
+		diags := resourceInstanceSetPowerState(ctx, service, d, "offline", 50*time.Millisecond)
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail, "timeout while waiting for state")
+
+		instance, _ := service.GetInstance(d.Id())
+		assert.True(t, *instance.Online)
+	})
+}
+
+// resizeRecordingService records the power and patch operations made against instances
+type resizeRecordingService struct {
+	*fakeECloudService
+
+	operations []string
+	// failPatchVolume and failPowerOn cause the respective requests to fail
+	failPatchVolume bool
+	failPowerOn     bool
+	// patchVolumeDelay delays volume patch requests, as a slow API would
+	patchVolumeDelay time.Duration
+}
+
+func (s *resizeRecordingService) PowerShutdownInstance(instanceID string) (string, error) {
+	s.operations = append(s.operations, "shutdown")
+	return s.fakeECloudService.PowerShutdownInstance(instanceID)
+}
+
+func (s *resizeRecordingService) PowerOnInstance(instanceID string) (string, error) {
+	s.operations = append(s.operations, "power on")
+	if s.failPowerOn {
+		return "", fmt.Errorf("power on failed")
+	}
+	return s.fakeECloudService.PowerOnInstance(instanceID)
+}
+
+func (s *resizeRecordingService) PatchInstance(instanceID string, req ecloudservice.PatchInstanceRequest) error {
+	s.operations = append(s.operations, "patch instance")
+	return s.fakeECloudService.PatchInstance(instanceID, req)
+}
+
+func (s *resizeRecordingService) PatchVolume(volumeID string, req ecloudservice.PatchVolumeRequest) (ecloudservice.TaskReference, error) {
+	s.operations = append(s.operations, "patch volume")
+	time.Sleep(s.patchVolumeDelay)
+	if s.failPatchVolume {
+		return ecloudservice.TaskReference{}, fmt.Errorf("volume resize failed")
+	}
+	return s.fakeECloudService.PatchVolume(volumeID, req)
+}
+
+func TestUnitInstance_resizeStrategy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	config := func(resizeStrategy string, ramCapacity int, volumeCapacity int) map[string]interface{} {
+		return map[string]interface{}{
+			"vpc_id":          "vpc-abcdef12",
+			"network_id":      "net-abcdef12",
+			"image_id":        "img-abcdef12",
+			"ram_capacity":    ramCapacity,
+			"volume_capacity": volumeCapacity,
+			"resize_strategy": resizeStrategy,
+			"vcpu": []interface{}{
+				map[string]interface{}{"sockets": 1, "cores_per_socket": 1},
+			},
+		}
+	}
+
+	// resize creates an instance, then applies given configuration to it
+	// resizeWithin resizes the instance with given update timeout, where set
+	resizeWithin := func(t *testing.T, service *resizeRecordingService, resizeStrategy string, timeout string) (string, diag.Diagnostics) {
+		d := schema.TestResourceDataRaw(t, resourceInstance().Schema, config(resizeStrategy, 2048, 40))
+		diags := resourceInstanceCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		raw := config(resizeStrategy, 4096, 60)
+		if timeout != "" {
+			raw["timeouts"] = map[string]interface{}{"update": timeout}
+		}
+
+		r := resourceInstance()
+		diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), service)
+		assert.Nil(t, err)
+
+		_, diags = r.Apply(ctx, d.State(), diff, service)
+		return d.Id(), diags
+	}
+
+	resize := func(t *testing.T, service *resizeRecordingService, resizeStrategy string) (string, diag.Diagnostics) {
+		return resizeWithin(t, service, resizeStrategy, "")
+	}
+
+	t.Run("Hot", func(t *testing.T) {
+		service := &resizeRecordingService{fakeECloudService: newFakeECloudService()}
+
+		_, diags := resize(t, service, "hot")
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, []string{"patch instance", "patch volume"}, service.operations)
+	})
+
+	t.Run("ShutdownRestart", func(t *testing.T) {
+		service := &resizeRecordingService{fakeECloudService: newFakeECloudService()}
+
+		instanceID, diags := resize(t, service, "shutdown_restart")
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, []string{"shutdown", "patch instance", "patch volume", "power on"}, service.operations)
+
+		instance, _ := service.GetInstance(instanceID)
+		assert.True(t, *instance.Online)
+		assert.Equal(t, 4096, instance.RAMCapacity)
+	})
+
+	t.Run("ShutdownRestart_ResizeFailed_PowersOn", func(t *testing.T) {
+		service := &resizeRecordingService{fakeECloudService: newFakeECloudService()}
+		service.failPatchVolume = true
+
+		instanceID, diags := resize(t, service, "shutdown_restart")
+		assert.True(t, diags.HasError())
+		assert.Len(t, diags, 1)
+		assert.Contains(t, diags[0].Summary, "Error updating volume")
+		assert.Equal(t, []string{"shutdown", "patch instance", "patch volume", "power on"}, service.operations)
+
+		instance, _ := service.GetInstance(instanceID)
+		assert.True(t, *instance.Online)
+	})
+
+	t.Run("ShutdownRestart_ResizeTimedOut_PowersOn", func(t *testing.T) {
+		service := &resizeRecordingService{fakeECloudService: newFakeECloudService()}
+		service.failPatchVolume = true
+		service.patchVolumeDelay = 200 * time.Millisecond
+
+		instanceID, diags := resizeWithin(t, service, "shutdown_restart", "100ms")
+		assert.Len(t, diags, 1)
+		assert.Contains(t, diags[0].Summary, "Error updating volume")
+		assert.Equal(t, []string{"shutdown", "patch instance", "patch volume", "power on"}, service.operations)
+
+		instance, _ := service.GetInstance(instanceID)
+		assert.True(t, *instance.Online)
+	})
+
+	t.Run("ShutdownRestart_ResizeFailed_PowerOnFailed_ReturnsError", func(t *testing.T) {
+		service := &resizeRecordingService{fakeECloudService: newFakeECloudService()}
+		service.failPatchVolume = true
+		service.failPowerOn = true
+
+		instanceID, diags := resize(t, service, "shutdown_restart")
+		assert.Len(t, diags, 2)
+		assert.Equal(t, fmt.Sprintf("Error powering on instance with ID [%s] following failed resize", instanceID), diags[1].Summary)
+		assert.Contains(t, diags[1].Detail, "may have been left offline")
+
+		instance, _ := service.GetInstance(instanceID)
+		assert.False(t, *instance.Online)
+	})
+
+	t.Run("GuestDoesNotReturn_ReturnsError", func(t *testing.T) {
+		service := newFakeECloudService()
+		service.StayOffline = true
+		d := schema.TestResourceDataRaw(t, resourceInstance().Schema, config("shutdown_restart", 2048, 40))
+		diags := resourceInstanceCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		service.PowerShutdownInstance(d.Id())
+
+		diags = resourceInstanceStartAfterResize(ctx, service, d, 50*time.Millisecond)
+		assert.True(t, diags.HasError())
+		assert.Equal(t, fmt.Sprintf("Error waiting for instance with ID [%s] to come online after resize", d.Id()), diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "last state: 'offline'")
+	})
+}
+
+func TestUnitInstance_networkInterfaces(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	config := func(networkInterfaces ...map[string]interface{}) map[string]interface{} {
+		rawInterfaces := make([]interface{}, len(networkInterfaces))
+		for i, networkInterface := range networkInterfaces {
+			rawInterfaces[i] = networkInterface
+		}
+
+		return map[string]interface{}{
+			"vpc_id":            "vpc-abcdef12",
+			"image_id":          "img-abcdef12",
+			"ram_capacity":      2048,
+			"volume_capacity":   40,
+			"network_interface": rawInterfaces,
+			"vcpu": []interface{}{
+				map[string]interface{}{"sockets": 1, "cores_per_socket": 1},
+			},
+		}
+	}
+
+	create := func(t *testing.T, service *fakeECloudService, raw map[string]interface{}) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceInstance().Schema, raw)
+		diags := resourceInstanceCreate(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		return d
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		service := newFakeECloudService()
+		fip, _ := service.CreateFloatingIP(ecloudservice.CreateFloatingIPRequest{VPCID: "vpc-abcdef12"})
+
+		d := create(t, service, config(
+			map[string]interface{}{"network_id": "net-a", "ip_address": "10.0.0.5"},
+			map[string]interface{}{"network_id": "net-b", "floating_ip_id": fip.ResourceID},
+			map[string]interface{}{"network_id": "net-c"},
+		))
+
+		nics, _ := service.GetInstanceNICs(d.Id(), connection.APIRequestParameters{})
+		assert.Len(t, nics, 3)
+		for i, networkID := range []string{"net-a", "net-b", "net-c"} {
+			assert.Equal(t, nics[i].ID, d.Get(fmt.Sprintf("network_interface.%d.id", i)))
+			assert.Equal(t, networkID, d.Get(fmt.Sprintf("network_interface.%d.network_id", i)))
+			assert.NotEmpty(t, d.Get(fmt.Sprintf("network_interface.%d.mac_address", i)))
+		}
+		assert.Equal(t, "10.0.0.5", d.Get("network_interface.0.ip_address"))
+		assert.Equal(t, nics[0].ID, d.Get("nic_id"))
+
+		floatingIP, _ := service.GetFloatingIP(fip.ResourceID)
+		assert.Equal(t, nics[1].ID, floatingIP.ResourceID)
+		assert.Equal(t, fip.ResourceID, d.Get("network_interface.1.floating_ip_id"))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		service := newFakeECloudService()
+		fip, _ := service.CreateFloatingIP(ecloudservice.CreateFloatingIPRequest{VPCID: "vpc-abcdef12"})
+
+		d := create(t, service, config(
+			map[string]interface{}{"network_id": "net-a"},
+			map[string]interface{}{"network_id": "net-b", "floating_ip_id": fip.ResourceID},
+			map[string]interface{}{"network_id": "net-c"},
+		))
+		primaryID := d.Get("network_interface.0.id").(string)
+
+		r := resourceInstance()
+		diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config(
+			map[string]interface{}{"network_id": "net-a", "floating_ip_id": fip.ResourceID},
+			map[string]interface{}{"network_id": "net-d"},
+		)), service)
+		assert.Nil(t, err)
+		assert.False(t, diff.RequiresNew())
+
+		state, diags := r.Apply(ctx, d.State(), diff, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		nics, _ := service.GetInstanceNICs(d.Id(), connection.APIRequestParameters{})
+		assert.Len(t, nics, 2)
+		assert.Equal(t, primaryID, nics[0].ID)
+		assert.Equal(t, "net-d", nics[1].NetworkID)
+		assert.Equal(t, "2", state.Attributes["network_interface.#"])
+		assert.Equal(t, nics[1].ID, state.Attributes["network_interface.1.id"])
+
+		floatingIP, _ := service.GetFloatingIP(fip.ResourceID)
+		assert.Equal(t, primaryID, floatingIP.ResourceID)
+	})
+
+	t.Run("ReadRemovedNIC", func(t *testing.T) {
+		service := newFakeECloudService()
+		d := create(t, service, config(
+			map[string]interface{}{"network_id": "net-a"},
+			map[string]interface{}{"network_id": "net-b"},
+		))
+
+		service.Remove(d.Get("network_interface.1.id").(string))
+
+		diags := resourceInstanceRead(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, 2, d.Get("network_interface.#"))
+		assert.Equal(t, "", d.Get("network_interface.1.id"))
+		assert.Equal(t, "", d.Get("network_interface.1.network_id"))
+	})
+
+	t.Run("PrimaryNetworkChanged_RequiresNew", func(t *testing.T) {
+		service := newFakeECloudService()
+		d := create(t, service, config(map[string]interface{}{"network_id": "net-a"}))
+
+		diff, err := resourceInstance().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config(
+			map[string]interface{}{"network_id": "net-b"},
+		)), service)
+		assert.Nil(t, err)
+		assert.True(t, diff.RequiresNew())
+	})
+
+	t.Run("MigrateWithExtraNIC_KeepsNIC", func(t *testing.T) {
+		service := newFakeECloudService()
+		legacy := config()
+		delete(legacy, "network_interface")
+		legacy["network_id"] = "net-a"
+		d := create(t, service, legacy)
+		primaryID := d.Get("nic_id").(string)
+
+		// NIC managed by an ecloud_nic resource
+		task, _ := service.CreateNIC(ecloudservice.CreateNICRequest{InstanceID: d.Id(), NetworkID: "net-b"})
+		extraID := task.ResourceID
+
+		diags := resourceInstanceRead(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, 1, d.Get("network_interface.#"))
+		assert.Equal(t, primaryID, d.Get("network_interface.0.id"))
+
+		r := resourceInstance()
+		migrated := config(map[string]interface{}{"network_id": "net-a"})
+		diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(migrated), service)
+		assert.Nil(t, err)
+		assert.False(t, diff.RequiresNew())
+
+		state, diags := r.Apply(ctx, d.State(), diff, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, "", state.Attributes["network_id"])
+		assert.Equal(t, "1", state.Attributes["network_interface.#"])
+		assert.Equal(t, primaryID, state.Attributes["network_interface.0.id"])
+
+		_, err = service.GetNIC(extraID)
+		assert.Nil(t, err)
+
+		// NICs attached later by ecloud_nic resources don't appear in the plan
+		service.CreateNIC(ecloudservice.CreateNICRequest{InstanceID: d.Id(), NetworkID: "net-c"})
+		state, diags = r.RefreshWithoutUpgrade(ctx, state, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+		diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(migrated), service)
+		assert.Nil(t, err)
+		assert.True(t, diff == nil || diff.Empty())
+	})
+
+	t.Run("ImportWithSingleNIC_ReadsPrimaryNIC", func(t *testing.T) {
+		service := newFakeECloudService()
+		created := create(t, service, config(map[string]interface{}{"network_id": "net-a"}))
+
+		d := resourceInstance().TestResourceData()
+		d.SetId(created.Id())
+
+		diags := resourceInstanceRead(ctx, d, service)
+		assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+		assert.Equal(t, created.Get("nic_id"), d.Get("nic_id"))
+		assert.Equal(t, 1, d.Get("network_interface.#"))
+		assert.Equal(t, "net-a", d.Get("network_interface.0.network_id"))
+	})
+
+	t.Run("ImportWithMultipleNICs_ReturnsError", func(t *testing.T) {
+		service := newFakeECloudService()
+		created := create(t, service, config(map[string]interface{}{"network_id": "net-a"}))
+
+		// NIC managed by an ecloud_nic resource, which may be listed before the primary NIC
+		service.CreateNIC(ecloudservice.CreateNICRequest{InstanceID: created.Id(), NetworkID: "net-b"})
+
+		d := resourceInstance().TestResourceData()
+		d.SetId(created.Id())
+
+		diags := resourceInstanceRead(ctx, d, service)
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Unexpected number of instance nics. Unable to lookup floating ip", diags[0].Summary)
+		assert.Equal(t, "", d.Get("nic_id"))
+	})
+
+	t.Run("LegacyNetworkChanged_RequiresNew", func(t *testing.T) {
+		service := newFakeECloudService()
+		legacy := config()
+		delete(legacy, "network_interface")
+		legacy["network_id"] = "net-a"
+		d := create(t, service, legacy)
+
+		legacy["network_id"] = "net-b"
+		diff, err := resourceInstance().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(legacy), service)
+		assert.Nil(t, err)
+		assert.True(t, diff.RequiresNew())
+	})
+
+	t.Run("SecondaryIPAddress_ReturnsError", func(t *testing.T) {
+		service := newFakeECloudService()
+
+		_, err := resourceInstance().Diff(ctx, nil, terraform.NewResourceConfigRaw(config(
+			map[string]interface{}{"network_id": "net-a"},
+			map[string]interface{}{"network_id": "net-b", "ip_address": "10.0.1.5"},
+		)), service)
+		assert.EqualError(t, err, "network_interface.1.ip_address cannot be set, fixed IP addresses are only supported by the first network interface")
+	})
+}
@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -35,15 +34,18 @@ func resourceIPAddress() *schema.Resource {
 				Computed: true,
 			},
 			"ip_address": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateIPAddress,
 			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -66,17 +68,16 @@ func resourceIPAddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	d.SetId(task.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "IP address",
+		ResourceID: d.Id(),
+		TaskID:     task.TaskID,
+		Sync:       IPAddressSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for IP address with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceIPAddressRead(ctx, d, meta)
@@ -122,17 +123,16 @@ func resourceIPAddressUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			return diag.Errorf("Error updating IP address with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "IP address",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       IPAddressSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for IP address with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -155,18 +155,32 @@ func resourceIPAddressDelete(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "IP address",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       IPAddressSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for IP address with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// IPAddressSyncFunc returns a ResourceSyncFunc retrieving the sync state of the IP address with given ID
+func IPAddressSyncFunc(service ecloudservice.ECloudService, ipID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		ipAddress, err := service.GetIPAddress(ipID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.IPAddressNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &ipAddress.Sync, nil
+	}
+}
@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,7 +18,15 @@ func resourceLoadBalancer() *schema.Resource {
 		UpdateContext: resourceLoadBalancerUpdate,
 		DeleteContext: resourceLoadBalancerDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByVPCName("load balancer", lookupIDsByName(ecloudservice.ECloudService.GetLoadBalancers, func(lb ecloudservice.LoadBalancer) (string, string) {
+				return lb.Name, lb.ID
+			})),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -77,17 +84,16 @@ func resourceLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, met
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "load balancer",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       LoadBalancerSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for loadbalancer with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceLoadBalancerRead(ctx, d, meta)
@@ -136,17 +142,16 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, met
 			return diag.Errorf("Error updating loadbalancer with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "load balancer",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       LoadBalancerSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      3 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for loadbalancer with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -169,18 +174,32 @@ func resourceLoadBalancerDelete(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "load balancer",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       LoadBalancerSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for loadbalancer with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// LoadBalancerSyncFunc returns a ResourceSyncFunc retrieving the sync state of the load balancer with given ID
+func LoadBalancerSyncFunc(service ecloudservice.ECloudService, loadBalancerID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		loadBalancer, err := service.GetLoadBalancer(loadBalancerID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.LoadBalancerNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &loadBalancer.Sync, nil
+	}
+}
@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +22,12 @@ func resourceLoadBalancerVip() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -65,17 +70,16 @@ func resourceLoadBalancerVipCreate(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "load balancer VIP",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VIPSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      3 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for loadbalancer vip with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceLoadBalancerVipRead(ctx, d, meta)
@@ -138,17 +142,16 @@ func resourceLoadBalancerVipUpdate(ctx context.Context, d *schema.ResourceData,
 			return diag.Errorf("Error updating loadbalancer vip with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "load balancer VIP",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VIPSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      3 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for loadbalancer vip with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -173,17 +176,16 @@ func resourceLoadBalancerVipDelete(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "load balancer VIP",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VIPSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for loadbalancer vip with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	// remove floating ip if set
@@ -206,18 +208,33 @@ func resourceLoadBalancerVipDelete(ctx context.Context, d *schema.ResourceData,
 			}
 		}
 
-		stateConf = &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+		diags = waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "delete",
+			Resource:   "floating IP",
+			ResourceID: fip,
+			TaskID:     taskID,
+			Sync:       FloatingIPSyncFunc(service, fip),
 			Timeout:    d.Timeout(schema.TimeoutDelete),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for floating ip with ID [%s] to be removed: %s", d.Id(), err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
 	return nil
 }
+
+// VIPSyncFunc returns a ResourceSyncFunc retrieving the sync state of the load balancer VIP with given ID
+func VIPSyncFunc(service ecloudservice.ECloudService, vipID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		vip, err := service.GetVIP(vipID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VIPNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &vip.Sync, nil
+	}
+}
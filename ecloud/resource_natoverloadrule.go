@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceNATOverloadRule() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"network_id": {
 				Type:     schema.TypeString,
@@ -34,13 +39,15 @@ func resourceNATOverloadRule() *schema.Resource {
 				ForceNew: true,
 			},
 			"subnet": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateCIDR,
 			},
 			"action": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateEnum(ecloudservice.NATOverloadRuleActionEnum),
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -76,17 +83,16 @@ func resourceNATOverloadRuleCreate(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "NAT overload rule",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       NATOverloadRuleSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskRef.TaskID, ecloudservice.TaskStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceNATOverloadRuleRead(ctx, d, meta)
@@ -141,17 +147,16 @@ func resourceNATOverloadRuleUpdate(ctx context.Context, d *schema.ResourceData,
 			return diag.Errorf("Error updating network with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskRef.TaskID, ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "NAT overload rule",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       NATOverloadRuleSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -166,21 +171,40 @@ func resourceNATOverloadRuleDelete(ctx context.Context, d *schema.ResourceData,
 	})
 	taskID, err := service.DeleteNATOverloadRule(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing network with ID [%s]: %s", d.Id(), err)
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+		switch err.(type) {
+		case *ecloudservice.NATOverloadRuleNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing NAT overload rule with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for task with ID [%s] to return task status of [%s]: %s", taskID, ecloudservice.TaskStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "NAT overload rule",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       NATOverloadRuleSyncFunc(service, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// NATOverloadRuleSyncFunc returns a ResourceSyncFunc retrieving the sync state of the NAT overload rule with given ID
+func NATOverloadRuleSyncFunc(service ecloudservice.ECloudService, ruleID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		rule, err := service.GetNATOverloadRule(ruleID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.NATOverloadRuleNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &rule.Sync, nil
+	}
+}
@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,7 +18,15 @@ func resourceNetwork() *schema.Resource {
 		UpdateContext: resourceNetworkUpdate,
 		DeleteContext: resourceNetworkDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByVPCName("network", lookupRouterObjectIDsByName(ecloudservice.ECloudService.GetNetworks, func(network ecloudservice.Network) (string, string) {
+				return network.Name, network.ID
+			})),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -29,9 +36,10 @@ func resourceNetwork() *schema.Resource {
 				ForceNew: true,
 			},
 			"subnet": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateCIDR,
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -60,17 +68,15 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, meta int
 
 	d.SetId(networkID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    NetworkSyncStatusRefreshFunc(service, networkID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "network",
+		ResourceID: networkID,
+		Sync:       NetworkSyncFunc(service, networkID),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for network with ID [%s] to return sync status of [%s]: %s", networkID, ecloudservice.SyncStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceNetworkRead(ctx, d, meta)
@@ -116,17 +122,15 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta int
 			return diag.Errorf("Error updating network with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    NetworkSyncStatusRefreshFunc(service, d.Id()),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "network",
+			ResourceID: d.Id(),
+			Sync:       NetworkSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 1 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for network with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.SyncStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -141,41 +145,39 @@ func resourceNetworkDelete(ctx context.Context, d *schema.ResourceData, meta int
 	})
 	err := service.DeleteNetwork(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing network with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.NetworkNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing network with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"Deleted"},
-		Refresh:    NetworkSyncStatusRefreshFunc(service, d.Id()),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "network",
+		ResourceID: d.Id(),
+		Sync:       NetworkSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for network with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
-// NetworkSyncStatusRefreshFunc returns a function with StateRefreshFunc signature for use
-// with StateChangeConf
-func NetworkSyncStatusRefreshFunc(service ecloudservice.ECloudService, networkID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+// NetworkSyncFunc returns a ResourceSyncFunc retrieving the sync state of the network with given ID
+func NetworkSyncFunc(service ecloudservice.ECloudService, networkID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
 		network, err := service.GetNetwork(networkID)
 		if err != nil {
 			if _, ok := err.(*ecloudservice.NetworkNotFoundError); ok {
-				return network, "Deleted", nil
+				return nil, nil
 			}
-			return nil, "", err
-		}
-
-		if network.Sync.Status == ecloudservice.SyncStatusFailed {
-			return nil, "", fmt.Errorf("Failed to create/update network - review logs")
+			return nil, err
 		}
 
-		return network, network.Sync.Status.String(), nil
+		return &network.Sync, nil
 	}
 }
@@ -1,6 +1,7 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccNetwork_basic(t *testing.T) {
@@ -110,3 +112,32 @@ resource "ecloud_network" "test-network" {
 }
 `, networkName, subnet)
 }
+
+func TestUnitNetwork_importByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	routerID, _ := service.CreateRouter(ecloudservice.CreateRouterRequest{VPCID: "vpc-abcdef12"})
+	otherRouterID, _ := service.CreateRouter(ecloudservice.CreateRouterRequest{VPCID: "vpc-other"})
+	networkID, _ := service.CreateNetwork(ecloudservice.CreateNetworkRequest{RouterID: routerID, Name: "frontend"})
+	service.CreateNetwork(ecloudservice.CreateNetworkRequest{RouterID: otherRouterID, Name: "frontend"})
+	importer := resourceNetwork().Importer.StateContext
+
+	t.Run("Name", func(t *testing.T) {
+		d := resourceNetwork().TestResourceData()
+		d.SetId("vpc-abcdef12:name=frontend")
+
+		_, err := importer(ctx, d, service)
+		assert.Nil(t, err)
+		assert.Equal(t, networkID, d.Id())
+	})
+
+	t.Run("VPCWithoutRouters_ReturnsError", func(t *testing.T) {
+		d := resourceNetwork().TestResourceData()
+		d.SetId("vpc-empty:name=frontend")
+
+		_, err := importer(ctx, d, service)
+		assert.EqualError(t, err, "No network found with name [frontend] in VPC [vpc-empty]")
+	})
+}
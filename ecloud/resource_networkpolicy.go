@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +22,12 @@ func resourceNetworkPolicy() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"network_id": {
 				Type:     schema.TypeString,
@@ -38,9 +43,10 @@ func resourceNetworkPolicy() *schema.Resource {
 				Optional: true,
 			},
 			"catchall_rule_action": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  ecloudservice.NetworkPolicyCatchallRuleActionReject.String(),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          ecloudservice.NetworkPolicyCatchallRuleActionReject.String(),
+				ValidateDiagFunc: validateEnum(ecloudservice.NetworkPolicyCatchallRuleActionEnum),
 			},
 			"catchall_rule_id": {
 				Type:     schema.TypeString,
@@ -76,17 +82,16 @@ func resourceNetworkPolicyCreate(ctx context.Context, d *schema.ResourceData, me
 
 	d.SetId(task.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "network policy",
+		ResourceID: task.ResourceID,
+		TaskID:     task.TaskID,
+		Sync:       NetworkPolicySyncFunc(service, task.ResourceID),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for network policy with ID [%s] to return status of [%s]: %s", task.ResourceID, ecloudservice.TaskStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceNetworkPolicyRead(ctx, d, meta)
@@ -160,17 +165,16 @@ func resourceNetworkPolicyUpdate(ctx context.Context, d *schema.ResourceData, me
 			return diag.Errorf("Error updating networking policy with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "network policy",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       NetworkPolicySyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 1 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for network policy with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -198,17 +202,17 @@ func resourceNetworkPolicyUpdate(ctx context.Context, d *schema.ResourceData, me
 			return diag.Errorf("Error updating network rule action: %s", err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 1 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for network rule with ID [%s] to be deleted: %s", rule.ID, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:    "delete",
+			Resource:     "network rule",
+			ResourceID:   rule.ID,
+			TaskID:       task.TaskID,
+			Sync:         NetworkPolicySyncFunc(service, d.Id()),
+			SyncResource: fmt.Sprintf("network policy [%s]", d.Id()),
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -223,21 +227,40 @@ func resourceNetworkPolicyDelete(ctx context.Context, d *schema.ResourceData, me
 	})
 	taskID, err := service.DeleteNetworkPolicy(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing network policy with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.NetworkPolicyNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing network policy with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "network policy",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       NetworkPolicySyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for network policy with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// NetworkPolicySyncFunc returns a ResourceSyncFunc retrieving the sync state of the network policy with given ID
+func NetworkPolicySyncFunc(service ecloudservice.ECloudService, policyID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		networkPolicy, err := service.GetNetworkPolicy(policyID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.NetworkPolicyNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &networkPolicy.Sync, nil
+	}
+}
@@ -10,7 +10,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/ukfast/terraform-provider-ecloud/pkg/lock"
 )
@@ -25,6 +24,12 @@ func resourceNetworkRule() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"network_policy_id": {
 				Type:     schema.TypeString,
@@ -41,12 +46,14 @@ func resourceNetworkRule() *schema.Resource {
 				Required: true,
 			},
 			"direction": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(ecloudservice.NetworkRuleDirectionEnum),
 			},
 			"action": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(ecloudservice.NetworkRuleActionEnum),
 			},
 			"source": {
 				Type:     schema.TypeString,
@@ -66,8 +73,9 @@ func resourceNetworkRule() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"protocol": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateEnum(ecloudservice.NetworkRulePortProtocolEnum),
 						},
 						"source": {
 							Type:     schema.TypeString,
@@ -91,7 +99,10 @@ func resourceNetworkRule() *schema.Resource {
 
 func resourceNetworkRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	networkPolicyID := d.Get("network_policy_id").(string)
-	unlock := lock.LockResource(networkPolicyID)
+	unlock, err := lock.LockResource(ctx, networkPolicyID)
+	if err != nil {
+		return diag.Errorf("Error locking network policy with ID [%s]: %s", networkPolicyID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -135,17 +146,17 @@ func resourceNetworkRuleCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(task.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for network policy with ID [%s] to return task status of [%s]: %s", networkPolicyID, ecloudservice.TaskStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:    "create",
+		Resource:     "network rule",
+		ResourceID:   d.Id(),
+		SyncResource: fmt.Sprintf("network policy [%s]", networkPolicyID),
+		TaskID:       task.TaskID,
+		Sync:         NetworkPolicySyncFunc(service, networkPolicyID),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceNetworkRuleRead(ctx, d, meta)
@@ -198,7 +209,10 @@ func resourceNetworkRuleRead(ctx context.Context, d *schema.ResourceData, meta i
 
 func resourceNetworkRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	networkPolicyID := d.Get("network_policy_id").(string)
-	unlock := lock.LockResource(networkPolicyID)
+	unlock, err := lock.LockResource(ctx, networkPolicyID)
+	if err != nil {
+		return diag.Errorf("Error locking network policy with ID [%s]: %s", networkPolicyID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -275,17 +289,17 @@ func resourceNetworkRuleUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error updating firewall rule with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 1 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for network policy with ID [%s] to return task status of [%s]: %s", networkPolicyID, ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:    "update",
+			Resource:     "network rule",
+			ResourceID:   d.Id(),
+			SyncResource: fmt.Sprintf("network policy [%s]", networkPolicyID),
+			TaskID:       task.TaskID,
+			Sync:         NetworkPolicySyncFunc(service, networkPolicyID),
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -294,7 +308,10 @@ func resourceNetworkRuleUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceNetworkRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	networkPolicyID := d.Get("network_policy_id").(string)
-	unlock := lock.LockResource(networkPolicyID)
+	unlock, err := lock.LockResource(ctx, networkPolicyID)
+	if err != nil {
+		return diag.Errorf("Error locking network policy with ID [%s]: %s", networkPolicyID, err)
+	}
 	defer unlock()
 
 	service := meta.(ecloudservice.ECloudService)
@@ -304,20 +321,25 @@ func resourceNetworkRuleDelete(ctx context.Context, d *schema.ResourceData, meta
 	})
 	taskID, err := service.DeleteNetworkRule(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing network rule with ID [%s]: %s", d.Id(), err)
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
+		switch err.(type) {
+		case *ecloudservice.NetworkRuleNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing network rule with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for network policy with ID [%s] to return task status of [%s]: %s", networkPolicyID, ecloudservice.TaskStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:    "delete",
+		Resource:     "network rule",
+		ResourceID:   d.Id(),
+		SyncResource: fmt.Sprintf("network policy [%s]", networkPolicyID),
+		TaskID:       taskID,
+		Sync:         NetworkPolicySyncFunc(service, networkPolicyID),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,7 +18,7 @@ func resourceNICIPAddressBinding() *schema.Resource {
 		ReadContext:   resourceNICIPAddressBindingRead,
 		DeleteContext: resourceNICIPAddressBindingDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceNICIPAddressBindingImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -37,6 +36,7 @@ func resourceNICIPAddressBinding() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -57,17 +57,16 @@ func resourceNICIPAddressBindingCreate(ctx context.Context, d *schema.ResourceDa
 
 	d.SetId(getID(d.Get("nic_id").(string), d.Get("ip_address_id").(string)))
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "assign",
+		Resource:   "IP address",
+		ResourceID: d.Get("ip_address_id").(string),
+		TaskID:     taskID,
+		Sync:       IPAddressSyncFunc(service, d.Get("ip_address_id").(string)),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for IP address with ID [%s] to be bound to NIC: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceNICIPAddressBindingRead(ctx, d, meta)
@@ -79,19 +78,33 @@ func resourceNICIPAddressBindingRead(ctx context.Context, d *schema.ResourceData
 	nicID := d.Get("nic_id").(string)
 	ipAddressID := d.Get("ip_address_id").(string)
 
+	tflog.Info(ctx, "Retrieving NIC IP addresses", map[string]interface{}{
+		"nic_id":        nicID,
+		"ip_address_id": ipAddressID,
+	})
 	ipAddresses, err := service.GetNICIPAddresses(nicID, *connection.NewAPIRequestParameters().WithFilter(
 		*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{ipAddressID}),
 	))
 	if err != nil {
-		return diag.Errorf("Failed to retrieve IP addresses for NIC: %s", err)
+		switch err.(type) {
+		case *ecloudservice.NICNotFoundError:
+			d.SetId("")
+			return nil
+		default:
+			return diag.Errorf("Failed to retrieve IP addresses for NIC: %s", err)
+		}
 	}
 
-	retrievedIPAddressID := ""
-	if len(ipAddresses) == 1 {
-		retrievedIPAddressID = ipAddresses[0].ID
+	if len(ipAddresses) != 1 {
+		tflog.Info(ctx, "IP address no longer bound to NIC, removing from state", map[string]interface{}{
+			"nic_id":        nicID,
+			"ip_address_id": ipAddressID,
+		})
+		d.SetId("")
+		return nil
 	}
 
-	d.Set("ip_address_id", retrievedIPAddressID)
+	d.Set("ip_address_id", ipAddresses[0].ID)
 
 	return nil
 }
@@ -106,7 +119,12 @@ func resourceNICIPAddressBindingDelete(ctx context.Context, d *schema.ResourceDa
 		*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{ipAddressID}),
 	))
 	if err != nil {
-		return diag.Errorf("Failed to retrieve IP addresses for NIC: %s", err)
+		switch err.(type) {
+		case *ecloudservice.NICNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Failed to retrieve IP addresses for NIC: %s", err)
+		}
 	}
 
 	if len(ipAddresses) < 1 {
@@ -119,23 +137,56 @@ func resourceNICIPAddressBindingDelete(ctx context.Context, d *schema.ResourceDa
 	})
 	taskID, err := service.UnassignNICIPAddress(nicID, ipAddressID)
 	if err != nil {
-		return diag.Errorf("Error unassigning IP address [%s] from NIC [%s]: %s", ipAddressID, nicID, err)
+		switch err.(type) {
+		case *ecloudservice.NICNotFoundError, *ecloudservice.IPAddressNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error unassigning IP address [%s] from NIC [%s]: %s", ipAddressID, nicID, err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "unassign",
+		Resource:   "IP address",
+		ResourceID: ipAddressID,
+		TaskID:     taskID,
+		Sync:       IPAddressSyncFunc(service, ipAddressID),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 5 * time.Second,
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return nil
+}
+
+// resourceNICIPAddressBindingImport imports a binding using an ID of the form
+// <nic_id>/<ip_address_id>
+func resourceNICIPAddressBindingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	service := meta.(ecloudservice.ECloudService)
+
+	parts, err := parseCompositeImportID(d.Id(), "nic_id", "ip_address_id")
+	if err != nil {
+		return nil, err
 	}
+	nicID, ipAddressID := parts[0], parts[1]
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	ipAddresses, err := service.GetNICIPAddresses(nicID, *connection.NewAPIRequestParameters().WithFilter(
+		*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{ipAddressID}),
+	))
 	if err != nil {
-		return diag.Errorf("Error waiting for IP address with ID [%s] to be unassigned from NIC [%s]: %s", ipAddressID, nicID, err)
+		return nil, fmt.Errorf("Error retrieving IP addresses for NIC with ID [%s]: %s", nicID, err)
 	}
 
-	return nil
+	if len(ipAddresses) != 1 {
+		return nil, fmt.Errorf("IP address with ID [%s] is not bound to NIC with ID [%s]", ipAddressID, nicID)
+	}
+
+	d.SetId(getID(nicID, ipAddressID))
+	d.Set("nic_id", nicID)
+	d.Set("ip_address_id", ipAddressID)
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func getID(nicID string, ipAddressID string) string {
@@ -1,6 +1,7 @@
 package ecloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccIPAddressNICBinding_basic(t *testing.T) {
@@ -138,3 +140,25 @@ resource "ecloud_nic_ipaddress_binding" "ipaddress-1-binding-1" {
 }
 `
 }
+
+func TestUnitNICIPAddressBinding_import(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("NICNotFound_ReturnsError", func(t *testing.T) {
+		d := resourceNICIPAddressBinding().TestResourceData()
+		d.SetId("nic-abcdef12/ip-abcdef12")
+
+		_, err := resourceNICIPAddressBindingImport(ctx, d, &notFoundECloudService{})
+		assert.ErrorContains(t, err, "Error retrieving IP addresses for NIC with ID [nic-abcdef12]")
+	})
+
+	t.Run("InvalidID_ReturnsError", func(t *testing.T) {
+		d := resourceNICIPAddressBinding().TestResourceData()
+		d.SetId("nic-abcdef12")
+
+		_, err := resourceNICIPAddressBindingImport(ctx, d, &notFoundECloudService{})
+		assert.EqualError(t, err, "Unexpected format of ID [nic-abcdef12], expected <nic_id>/<ip_address_id>")
+	})
+}
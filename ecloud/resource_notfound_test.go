@@ -0,0 +1,358 @@
+package ecloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/poll"
+)
+
+// notFoundECloudService reports every object as not found, simulating objects which have
+// been removed outside of Terraform
+type notFoundECloudService struct {
+	ecloudservice.ECloudService
+}
+
+func (f *notFoundECloudService) Poller() *poll.Poller {
+	return poll.NewPoller(poll.Config{})
+}
+
+func (f *notFoundECloudService) GetAffinityRule(ruleID string) (ecloudservice.AffinityRule, error) {
+	return ecloudservice.AffinityRule{}, &ecloudservice.AffinityRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) DeleteAffinityRule(ruleID string) (string, error) {
+	return "", &ecloudservice.AffinityRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) GetAffinityRuleMember(memberID string) (ecloudservice.AffinityRuleMember, error) {
+	return ecloudservice.AffinityRuleMember{}, &ecloudservice.AffinityRuleMemberNotFoundError{ID: memberID}
+}
+
+func (f *notFoundECloudService) DeleteAffinityRuleMember(memberID string) (string, error) {
+	return "", &ecloudservice.AffinityRuleMemberNotFoundError{ID: memberID}
+}
+
+func (f *notFoundECloudService) GetBackupGateway(gatewayID string) (ecloudservice.BackupGateway, error) {
+	return ecloudservice.BackupGateway{}, &ecloudservice.BackupGatewayNotFoundError{ID: gatewayID}
+}
+
+func (f *notFoundECloudService) DeleteBackupGateway(gatewayID string) (string, error) {
+	return "", &ecloudservice.BackupGatewayNotFoundError{ID: gatewayID}
+}
+
+func (f *notFoundECloudService) GetFirewallPolicy(policyID string) (ecloudservice.FirewallPolicy, error) {
+	return ecloudservice.FirewallPolicy{}, &ecloudservice.FirewallPolicyNotFoundError{ID: policyID}
+}
+
+func (f *notFoundECloudService) DeleteFirewallPolicy(policyID string) (string, error) {
+	return "", &ecloudservice.FirewallPolicyNotFoundError{ID: policyID}
+}
+
+func (f *notFoundECloudService) GetFirewallRule(ruleID string) (ecloudservice.FirewallRule, error) {
+	return ecloudservice.FirewallRule{}, &ecloudservice.FirewallRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) DeleteFirewallRule(ruleID string) (string, error) {
+	return "", &ecloudservice.FirewallRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) GetFloatingIP(fipID string) (ecloudservice.FloatingIP, error) {
+	return ecloudservice.FloatingIP{}, &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+}
+
+func (f *notFoundECloudService) DeleteFloatingIP(fipID string) (string, error) {
+	return "", &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+}
+
+func (f *notFoundECloudService) GetHost(hostID string) (ecloudservice.Host, error) {
+	return ecloudservice.Host{}, &ecloudservice.HostNotFoundError{ID: hostID}
+}
+
+func (f *notFoundECloudService) DeleteHost(hostID string) (string, error) {
+	return "", &ecloudservice.HostNotFoundError{ID: hostID}
+}
+
+func (f *notFoundECloudService) GetHostGroup(hostGroupID string) (ecloudservice.HostGroup, error) {
+	return ecloudservice.HostGroup{}, &ecloudservice.HostGroupNotFoundError{ID: hostGroupID}
+}
+
+func (f *notFoundECloudService) DeleteHostGroup(hostGroupID string) (string, error) {
+	return "", &ecloudservice.HostGroupNotFoundError{ID: hostGroupID}
+}
+
+func (f *notFoundECloudService) GetImage(imageID string) (ecloudservice.Image, error) {
+	return ecloudservice.Image{}, &ecloudservice.ImageNotFoundError{ID: imageID}
+}
+
+func (f *notFoundECloudService) DeleteImage(imageID string) (string, error) {
+	return "", &ecloudservice.ImageNotFoundError{ID: imageID}
+}
+
+func (f *notFoundECloudService) GetInstance(instanceID string) (ecloudservice.Instance, error) {
+	return ecloudservice.Instance{}, &ecloudservice.InstanceNotFoundError{ID: instanceID}
+}
+
+func (f *notFoundECloudService) DeleteInstance(instanceID string) error {
+	return &ecloudservice.InstanceNotFoundError{ID: instanceID}
+}
+
+func (f *notFoundECloudService) GetIPAddress(ipID string) (ecloudservice.IPAddress, error) {
+	return ecloudservice.IPAddress{}, &ecloudservice.IPAddressNotFoundError{ID: ipID}
+}
+
+func (f *notFoundECloudService) DeleteIPAddress(ipID string) (string, error) {
+	return "", &ecloudservice.IPAddressNotFoundError{ID: ipID}
+}
+
+func (f *notFoundECloudService) GetLoadBalancer(loadbalancerID string) (ecloudservice.LoadBalancer, error) {
+	return ecloudservice.LoadBalancer{}, &ecloudservice.LoadBalancerNotFoundError{ID: loadbalancerID}
+}
+
+func (f *notFoundECloudService) DeleteLoadBalancer(loadbalancerID string) (string, error) {
+	return "", &ecloudservice.LoadBalancerNotFoundError{ID: loadbalancerID}
+}
+
+func (f *notFoundECloudService) GetVIP(vipID string) (ecloudservice.VIP, error) {
+	return ecloudservice.VIP{}, &ecloudservice.VIPNotFoundError{ID: vipID}
+}
+
+func (f *notFoundECloudService) DeleteVIP(vipID string) (string, error) {
+	return "", &ecloudservice.VIPNotFoundError{ID: vipID}
+}
+
+func (f *notFoundECloudService) GetNATOverloadRule(ruleID string) (ecloudservice.NATOverloadRule, error) {
+	return ecloudservice.NATOverloadRule{}, &ecloudservice.NATOverloadRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) DeleteNATOverloadRule(ruleID string) (string, error) {
+	return "", &ecloudservice.NATOverloadRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) GetNetwork(networkID string) (ecloudservice.Network, error) {
+	return ecloudservice.Network{}, &ecloudservice.NetworkNotFoundError{ID: networkID}
+}
+
+func (f *notFoundECloudService) DeleteNetwork(networkID string) error {
+	return &ecloudservice.NetworkNotFoundError{ID: networkID}
+}
+
+func (f *notFoundECloudService) GetNetworkPolicy(policyID string) (ecloudservice.NetworkPolicy, error) {
+	return ecloudservice.NetworkPolicy{}, &ecloudservice.NetworkPolicyNotFoundError{ID: policyID}
+}
+
+func (f *notFoundECloudService) DeleteNetworkPolicy(policyID string) (string, error) {
+	return "", &ecloudservice.NetworkPolicyNotFoundError{ID: policyID}
+}
+
+func (f *notFoundECloudService) GetNetworkRule(ruleID string) (ecloudservice.NetworkRule, error) {
+	return ecloudservice.NetworkRule{}, &ecloudservice.NetworkRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) DeleteNetworkRule(ruleID string) (string, error) {
+	return "", &ecloudservice.NetworkRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) GetNIC(nicID string) (ecloudservice.NIC, error) {
+	return ecloudservice.NIC{}, &ecloudservice.NICNotFoundError{ID: nicID}
+}
+
+func (f *notFoundECloudService) DeleteNIC(nicID string) (string, error) {
+	return "", &ecloudservice.NICNotFoundError{ID: nicID}
+}
+
+func (f *notFoundECloudService) GetRouter(routerID string) (ecloudservice.Router, error) {
+	return ecloudservice.Router{}, &ecloudservice.RouterNotFoundError{ID: routerID}
+}
+
+func (f *notFoundECloudService) DeleteRouter(routerID string) error {
+	return &ecloudservice.RouterNotFoundError{ID: routerID}
+}
+
+func (f *notFoundECloudService) GetSSHKeyPair(keypairID string) (ecloudservice.SSHKeyPair, error) {
+	return ecloudservice.SSHKeyPair{}, &ecloudservice.SSHKeyPairNotFoundError{ID: keypairID}
+}
+
+func (f *notFoundECloudService) DeleteSSHKeyPair(keypairID string) error {
+	return &ecloudservice.SSHKeyPairNotFoundError{ID: keypairID}
+}
+
+func (f *notFoundECloudService) GetTag(tagID string) (ecloudservice.Tag, error) {
+	return ecloudservice.Tag{}, &ecloudservice.TagNotFoundError{ID: tagID}
+}
+
+func (f *notFoundECloudService) DeleteTag(tagID string) error {
+	return &ecloudservice.TagNotFoundError{ID: tagID}
+}
+
+func (f *notFoundECloudService) GetVolume(volumeID string) (ecloudservice.Volume, error) {
+	return ecloudservice.Volume{}, &ecloudservice.VolumeNotFoundError{ID: volumeID}
+}
+
+func (f *notFoundECloudService) DeleteVolume(volumeID string) (string, error) {
+	return "", &ecloudservice.VolumeNotFoundError{ID: volumeID}
+}
+
+func (f *notFoundECloudService) GetVolumeGroup(groupID string) (ecloudservice.VolumeGroup, error) {
+	return ecloudservice.VolumeGroup{}, &ecloudservice.VolumeGroupNotFoundError{ID: groupID}
+}
+
+func (f *notFoundECloudService) DeleteVolumeGroup(groupID string) (string, error) {
+	return "", &ecloudservice.VolumeGroupNotFoundError{ID: groupID}
+}
+
+func (f *notFoundECloudService) GetVPC(vpcID string) (ecloudservice.VPC, error) {
+	return ecloudservice.VPC{}, &ecloudservice.VPCNotFoundError{ID: vpcID}
+}
+
+func (f *notFoundECloudService) DeleteVPC(vpcID string) error {
+	return &ecloudservice.VPCNotFoundError{ID: vpcID}
+}
+
+func (f *notFoundECloudService) GetVPNEndpoint(endpointID string) (ecloudservice.VPNEndpoint, error) {
+	return ecloudservice.VPNEndpoint{}, &ecloudservice.VPNEndpointNotFoundError{ID: endpointID}
+}
+
+func (f *notFoundECloudService) DeleteVPNEndpoint(endpointID string) (string, error) {
+	return "", &ecloudservice.VPNEndpointNotFoundError{ID: endpointID}
+}
+
+func (f *notFoundECloudService) GetVPNGateway(gatewayID string) (ecloudservice.VPNGateway, error) {
+	return ecloudservice.VPNGateway{}, &ecloudservice.VPNGatewayNotFoundError{ID: gatewayID}
+}
+
+func (f *notFoundECloudService) DeleteVPNGateway(gatewayID string) (string, error) {
+	return "", &ecloudservice.VPNGatewayNotFoundError{ID: gatewayID}
+}
+
+func (f *notFoundECloudService) GetVPNGatewayUser(userID string) (ecloudservice.VPNGatewayUser, error) {
+	return ecloudservice.VPNGatewayUser{}, &ecloudservice.VPNGatewayUserNotFoundError{ID: userID}
+}
+
+func (f *notFoundECloudService) DeleteVPNGatewayUser(userID string) (string, error) {
+	return "", &ecloudservice.VPNGatewayUserNotFoundError{ID: userID}
+}
+
+func (f *notFoundECloudService) GetVPNService(serviceID string) (ecloudservice.VPNService, error) {
+	return ecloudservice.VPNService{}, &ecloudservice.VPNServiceNotFoundError{ID: serviceID}
+}
+
+func (f *notFoundECloudService) DeleteVPNService(serviceID string) (string, error) {
+	return "", &ecloudservice.VPNServiceNotFoundError{ID: serviceID}
+}
+
+func (f *notFoundECloudService) GetVPNSession(sessionID string) (ecloudservice.VPNSession, error) {
+	return ecloudservice.VPNSession{}, &ecloudservice.VPNSessionNotFoundError{ID: sessionID}
+}
+
+func (f *notFoundECloudService) DeleteVPNSession(sessionID string) (string, error) {
+	return "", &ecloudservice.VPNSessionNotFoundError{ID: sessionID}
+}
+
+func (f *notFoundECloudService) GetAffinityRuleMembers(ruleID string, parameters connection.APIRequestParameters) ([]ecloudservice.AffinityRuleMember, error) {
+	return nil, &ecloudservice.AffinityRuleNotFoundError{ID: ruleID}
+}
+
+func (f *notFoundECloudService) UnassignFloatingIP(fipID string) (string, error) {
+	return "", &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+}
+
+func (f *notFoundECloudService) GetNICIPAddresses(nicID string, parameters connection.APIRequestParameters) ([]ecloudservice.IPAddress, error) {
+	return nil, &ecloudservice.NICNotFoundError{ID: nicID}
+}
+
+func (f *notFoundECloudService) UnassignNICIPAddress(nicID string, ipID string) (string, error) {
+	return "", &ecloudservice.NICNotFoundError{ID: nicID}
+}
+
+func (f *notFoundECloudService) PatchInstance(instanceID string, req ecloudservice.PatchInstanceRequest) error {
+	return &ecloudservice.InstanceNotFoundError{ID: instanceID}
+}
+
+func (f *notFoundECloudService) PatchVolume(volumeID string, patch ecloudservice.PatchVolumeRequest) (ecloudservice.TaskReference, error) {
+	return ecloudservice.TaskReference{}, &ecloudservice.VolumeNotFoundError{ID: volumeID}
+}
+
+func (f *notFoundECloudService) GetInstanceVolumes(instanceID string, parameters connection.APIRequestParameters) ([]ecloudservice.Volume, error) {
+	return nil, &ecloudservice.InstanceNotFoundError{ID: instanceID}
+}
+
+func (f *notFoundECloudService) GetVPNSessionPreSharedKey(sessionID string) (ecloudservice.VPNSessionPreSharedKey, error) {
+	return ecloudservice.VPNSessionPreSharedKey{}, &ecloudservice.VPNSessionNotFoundError{ID: sessionID}
+}
+
+func (f *notFoundECloudService) GetFirewallRuleFirewallRulePorts(firewallRuleID string, parameters connection.APIRequestParameters) ([]ecloudservice.FirewallRulePort, error) {
+	return nil, &ecloudservice.FirewallRuleNotFoundError{ID: firewallRuleID}
+}
+
+func TestUnitResources_notFound(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resource *schema.Resource
+		id       string
+		raw      map[string]interface{}
+	}{
+		{name: "ecloud_affinityrule", resource: resourceAffinityRule(), id: "ar-abcdef12"},
+		{name: "ecloud_affinityrule_member", resource: resourceAffinityRuleMember(), id: "arm-abcdef12"},
+		{name: "ecloud_backup_gateway", resource: resourceBackupGateway(), id: "bgw-abcdef12"},
+		{name: "ecloud_firewallpolicy", resource: resourceFirewallPolicy(), id: "fwp-abcdef12"},
+		{name: "ecloud_firewallrule", resource: resourceFirewallRule(), id: "fwr-abcdef12", raw: map[string]interface{}{"firewall_policy_id": "fwp-abcdef12"}},
+		{name: "ecloud_floatingip", resource: resourceFloatingIP(), id: "fip-abcdef12"},
+		{name: "ecloud_host", resource: resourceHost(), id: "h-abcdef12"},
+		{name: "ecloud_hostgroup", resource: resourceHostGroup(), id: "hg-abcdef12"},
+		{name: "ecloud_image", resource: resourceImage(), id: "img-abcdef12"},
+		{name: "ecloud_instance", resource: resourceInstance(), id: "i-abcdef12"},
+		{name: "ecloud_ipaddress", resource: resourceIPAddress(), id: "ip-abcdef12"},
+		{name: "ecloud_loadbalancer", resource: resourceLoadBalancer(), id: "lb-abcdef12"},
+		{name: "ecloud_loadbalancer_vip", resource: resourceLoadBalancerVip(), id: "vip-abcdef12"},
+		{name: "ecloud_natoverloadrule", resource: resourceNATOverloadRule(), id: "nor-abcdef12"},
+		{name: "ecloud_network", resource: resourceNetwork(), id: "net-abcdef12"},
+		{name: "ecloud_networkpolicy", resource: resourceNetworkPolicy(), id: "np-abcdef12"},
+		{name: "ecloud_networkrule", resource: resourceNetworkRule(), id: "nr-abcdef12", raw: map[string]interface{}{"network_policy_id": "np-abcdef12"}},
+		{name: "ecloud_nic", resource: resourceNIC(), id: "nic-abcdef12"},
+		{name: "ecloud_nic_ipaddress_binding", resource: resourceNICIPAddressBinding(), id: "nic-abcdef12.ip-abcdef12", raw: map[string]interface{}{"nic_id": "nic-abcdef12", "ip_address_id": "ip-abcdef12"}},
+		{name: "ecloud_router", resource: resourceRouter(), id: "rtr-abcdef12"},
+		{name: "ecloud_ssh_keypair", resource: resourceSshKeyPair(), id: "ssh-abcdef12"},
+		{name: "ecloud_tag", resource: resourceTag(), id: "tag-abcdef12"},
+		{name: "ecloud_volume", resource: resourceVolume(), id: "vol-abcdef12", raw: map[string]interface{}{"volume_group_id": "volgroup-abcdef12"}},
+		{name: "ecloud_volumegroup", resource: resourceVolumeGroup(), id: "volgroup-abcdef12"},
+		{name: "ecloud_volumegroup_instance", resource: resourceVolumeGroupInstance(), id: "i-abcdef12.volgroup-abcdef12", raw: map[string]interface{}{"instance_id": "i-abcdef12", "volume_group_id": "volgroup-abcdef12"}},
+		{name: "ecloud_vpc", resource: resourceVPC(), id: "vpc-abcdef12"},
+		{name: "ecloud_vpn_endpoint", resource: resourceVPNEndpoint(), id: "vpne-abcdef12"},
+		{name: "ecloud_vpn_gateway", resource: resourceVPNGateway(), id: "vpng-abcdef12"},
+		{name: "ecloud_vpn_gateway_user", resource: resourceVPNGatewayUser(), id: "vpngu-abcdef12"},
+		{name: "ecloud_vpn_service", resource: resourceVPNService(), id: "vpn-abcdef12"},
+		{name: "ecloud_vpn_session", resource: resourceVPNSession(), id: "vpns-abcdef12"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			service := &notFoundECloudService{}
+
+			t.Run("Read_RemovesFromState", func(t *testing.T) {
+				d := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.raw)
+				d.SetId(tc.id)
+
+				diags := tc.resource.ReadContext(ctx, d, service)
+				assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+				assert.Empty(t, d.Id())
+			})
+
+			t.Run("Delete_Succeeds", func(t *testing.T) {
+				d := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.raw)
+				d.SetId(tc.id)
+
+				diags := tc.resource.DeleteContext(ctx, d, service)
+				assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+			})
+		})
+	}
+}
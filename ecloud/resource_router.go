@@ -7,7 +7,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/net/context"
 )
@@ -19,7 +18,15 @@ func resourceRouter() *schema.Resource {
 		UpdateContext: resourceRouterUpdate,
 		DeleteContext: resourceRouterDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByVPCName("router", lookupIDsByName(ecloudservice.ECloudService.GetRouters, func(router ecloudservice.Router) (string, string) {
+				return router.Name, router.ID
+			})),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,17 +73,15 @@ func resourceRouterCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	d.SetId(routerID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    RouterSyncStatusRefreshFunc(service, routerID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "router",
+		ResourceID: routerID,
+		Sync:       RouterSyncFunc(service, routerID),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for router with ID [%s] to return sync status of [%s]: %s", routerID, ecloudservice.SyncStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceRouterRead(ctx, d, meta)
@@ -132,17 +137,15 @@ func resourceRouterUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 			return diag.Errorf("Error updating router with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    RouterSyncStatusRefreshFunc(service, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for router with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.SyncStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "router",
+			ResourceID: d.Id(),
+			Sync:       RouterSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -157,41 +160,39 @@ func resourceRouterDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	})
 	err := service.DeleteRouter(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing router with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.RouterNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing router with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"Deleted"},
-		Refresh:    RouterSyncStatusRefreshFunc(service, d.Id()),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "router",
+		ResourceID: d.Id(),
+		Sync:       RouterSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for router with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
-// RouterSyncStatusRefreshFunc returns a function with StateRefreshFunc signature for use
-// with StateChangeConf
-func RouterSyncStatusRefreshFunc(service ecloudservice.ECloudService, routerID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+// RouterSyncFunc returns a ResourceSyncFunc retrieving the sync state of the router with given ID
+func RouterSyncFunc(service ecloudservice.ECloudService, routerID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
 		router, err := service.GetRouter(routerID)
 		if err != nil {
 			if _, ok := err.(*ecloudservice.RouterNotFoundError); ok {
-				return router, "Deleted", nil
+				return nil, nil
 			}
-			return nil, "", err
-		}
-
-		if router.Sync.Status == ecloudservice.SyncStatusFailed {
-			return nil, "", fmt.Errorf("Failed to create/update router - review logs")
+			return nil, err
 		}
 
-		return router, router.Sync.Status.String(), nil
+		return &router.Sync, nil
 	}
 }
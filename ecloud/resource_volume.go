@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -20,7 +19,15 @@ func resourceVolume() *schema.Resource {
 		UpdateContext: resourceVolumeUpdate,
 		DeleteContext: resourceVolumeDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByVPCName("volume", lookupIDsByName(ecloudservice.ECloudService.GetVolumes, func(volume ecloudservice.Volume) (string, string) {
+				return volume.Name, volume.ID
+			})),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -102,17 +109,16 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "volume",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VolumeSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      3 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for volume with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVolumeRead(ctx, d, meta)
@@ -177,17 +183,16 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 			return diag.Errorf("Error updating volume with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "volume",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       VolumeSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for volume with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 	return resourceVolumeRead(ctx, d, meta)
@@ -208,20 +213,24 @@ func resourceVolumeDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 		task, err := service.PatchVolume(d.Id(), patchReq)
 		if err != nil {
-			return diag.Errorf("Error detaching volume with ID [%s] from volume group: %s", d.Id(), err)
-		}
-
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
+			switch err.(type) {
+			case *ecloudservice.VolumeNotFoundError:
+				return nil
+			default:
+				return diag.Errorf("Error detaching volume with ID [%s] from volume group: %s", d.Id(), err)
+			}
 		}
 
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for volume with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "detach",
+			Resource:   "volume",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       VolumeSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutDelete),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -239,18 +248,32 @@ func resourceVolumeDelete(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "volume",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VolumeSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for volume with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// VolumeSyncFunc returns a ResourceSyncFunc retrieving the sync state of the volume with given ID
+func VolumeSyncFunc(service ecloudservice.ECloudService, volumeID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		volume, err := service.GetVolume(volumeID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VolumeNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &volume.Sync, nil
+	}
+}
@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceVolumeGroup() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -60,17 +65,16 @@ func resourceVolumeGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "volume group",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VolumeGroupSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      3 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for volumegroup with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVolumeGroupRead(ctx, d, meta)
@@ -119,17 +123,16 @@ func resourceVolumeGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error updating volumegroup with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, task.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "volume group",
+			ResourceID: d.Id(),
+			TaskID:     task.TaskID,
+			Sync:       VolumeGroupSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for volumegroup with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 	return resourceVolumeGroupRead(ctx, d, meta)
@@ -151,18 +154,32 @@ func resourceVolumeGroupDelete(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.TaskStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "volume group",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VolumeGroupSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for volumegroup with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// VolumeGroupSyncFunc returns a ResourceSyncFunc retrieving the sync state of the volume group with given ID
+func VolumeGroupSyncFunc(service ecloudservice.ECloudService, groupID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		volumeGroup, err := service.GetVolumeGroup(groupID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VolumeGroupNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &volumeGroup.Sync, nil
+	}
+}
@@ -3,6 +3,7 @@ package ecloud
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ans-group/sdk-go/pkg/ptr"
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
@@ -17,7 +18,12 @@ func resourceVolumeGroupInstance() *schema.Resource {
 		ReadContext:   resourceVolumeGroupInstanceRead,
 		DeleteContext: resourceVolumeGroupInstanceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceVolumeGroupInstanceImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -57,14 +63,15 @@ func resourceVolumeGroupInstanceCreate(ctx context.Context, d *schema.ResourceDa
 
 	d.SetId(fmt.Sprintf("%s.%s", instanceID, volumeGroupID))
 
-	_, err = waitForResourceState(
-		ctx,
-		ecloudservice.SyncStatusComplete.String(),
-		InstanceSyncStatusRefreshFunc(service, instanceID),
-		d.Timeout(schema.TimeoutUpdate),
-	)
-	if err != nil {
-		return diag.Errorf("Error waiting for instance with ID [%s] to return sync status of [%s]: %s", instanceID, ecloudservice.SyncStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "update",
+		Resource:   "instance",
+		ResourceID: instanceID,
+		Sync:       InstanceSyncFunc(service, instanceID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVolumeGroupInstanceRead(ctx, d, meta)
@@ -75,9 +82,26 @@ func resourceVolumeGroupInstanceRead(ctx context.Context, d *schema.ResourceData
 
 	instanceID := d.Get("instance_id").(string)
 
+	tflog.Info(ctx, "Retrieving instance", map[string]interface{}{
+		"id": instanceID,
+	})
 	instance, err := service.GetInstance(instanceID)
 	if err != nil {
-		return diag.Errorf("Failed to retrieve instance: %s", err)
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			d.SetId("")
+			return nil
+		default:
+			return diag.Errorf("Failed to retrieve instance: %s", err)
+		}
+	}
+
+	if len(instance.VolumeGroupID) < 1 {
+		tflog.Info(ctx, "Instance no longer attached to volume group, removing from state", map[string]interface{}{
+			"id": instanceID,
+		})
+		d.SetId("")
+		return nil
 	}
 
 	d.Set("volume_group_id", instance.VolumeGroupID)
@@ -89,6 +113,25 @@ func resourceVolumeGroupInstanceDelete(ctx context.Context, d *schema.ResourceDa
 	service := meta.(ecloudservice.ECloudService)
 
 	instanceID := d.Get("instance_id").(string)
+	volumeGroupID := d.Get("volume_group_id").(string)
+
+	instance, err := service.GetInstance(instanceID)
+	if err != nil {
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Failed to retrieve instance: %s", err)
+		}
+	}
+
+	if instance.VolumeGroupID != volumeGroupID {
+		tflog.Debug(ctx, "Instance not attached to volume group, skipping detach", map[string]interface{}{
+			"id":              instanceID,
+			"volume_group_id": volumeGroupID,
+		})
+		return nil
+	}
 
 	patchReq := ecloudservice.PatchInstanceRequest{
 		VolumeGroupID: ptr.String(""),
@@ -99,20 +142,53 @@ func resourceVolumeGroupInstanceDelete(ctx context.Context, d *schema.ResourceDa
 	tflog.Info(ctx, "Updating instance", map[string]interface{}{
 		"id": instanceID,
 	})
-	err := service.PatchInstance(instanceID, patchReq)
+	err = service.PatchInstance(instanceID, patchReq)
 	if err != nil {
-		return diag.Errorf("Error updating instance with ID [%s]: %s", instanceID, err)
+		switch err.(type) {
+		case *ecloudservice.InstanceNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error updating instance with ID [%s]: %s", instanceID, err)
+		}
 	}
 
-	_, err = waitForResourceState(
-		ctx,
-		ecloudservice.SyncStatusComplete.String(),
-		InstanceSyncStatusRefreshFunc(service, instanceID),
-		d.Timeout(schema.TimeoutUpdate),
-	)
-	if err != nil {
-		return diag.Errorf("Error waiting for instance with ID [%s] to return sync status of [%s]: %s", instanceID, ecloudservice.SyncStatusComplete, err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "update",
+		Resource:   "instance",
+		ResourceID: instanceID,
+		Sync:       InstanceSyncFunc(service, instanceID),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// resourceVolumeGroupInstanceImport imports an attachment using an ID of the form
+// <instance_id>/<volume_group_id>
+func resourceVolumeGroupInstanceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	service := meta.(ecloudservice.ECloudService)
+
+	parts, err := parseCompositeImportID(d.Id(), "instance_id", "volume_group_id")
+	if err != nil {
+		return nil, err
+	}
+	instanceID, volumeGroupID := parts[0], parts[1]
+
+	instance, err := service.GetInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving instance with ID [%s]: %s", instanceID, err)
+	}
+
+	if instance.VolumeGroupID != volumeGroupID {
+		return nil, fmt.Errorf("Instance with ID [%s] is not attached to volume group with ID [%s]", instanceID, volumeGroupID)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", instanceID, volumeGroupID))
+	d.Set("instance_id", instanceID)
+	d.Set("volume_group_id", volumeGroupID)
+
+	return []*schema.ResourceData{d}, nil
+}
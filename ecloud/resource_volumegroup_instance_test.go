@@ -1,12 +1,17 @@
 package ecloud
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/ans-group/sdk-go/pkg/ptr"
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccVolumeGroupInstance_basic(t *testing.T) {
@@ -116,3 +121,93 @@ resource "ecloud_volumegroup_instance" "test-volume-group-instance" {
 }
 `
 }
+
+func TestUnitVolumeGroupInstance_detachedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+
+	d := schema.TestResourceDataRaw(t, resourceVolumeGroupInstance().Schema, map[string]interface{}{
+		"instance_id":     instanceID,
+		"volume_group_id": "volgroup-abcdef12",
+	})
+
+	diags := resourceVolumeGroupInstanceCreate(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Equal(t, "volgroup-abcdef12", d.Get("volume_group_id"))
+
+	service.PatchInstance(instanceID, ecloudservice.PatchInstanceRequest{VolumeGroupID: ptr.String("")})
+
+	diags = resourceVolumeGroupInstanceRead(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+	assert.Empty(t, d.Id())
+}
+
+func TestUnitVolumeGroupInstance_deleteSkipsOtherVolumeGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+	service.PatchInstance(instanceID, ecloudservice.PatchInstanceRequest{VolumeGroupID: ptr.String("volgroup-other")})
+
+	d := schema.TestResourceDataRaw(t, resourceVolumeGroupInstance().Schema, map[string]interface{}{
+		"instance_id":     instanceID,
+		"volume_group_id": "volgroup-abcdef12",
+	})
+	d.SetId(instanceID + ".volgroup-abcdef12")
+
+	diags := resourceVolumeGroupInstanceDelete(ctx, d, service)
+	assert.False(t, diags.HasError(), "unexpected error: %v", diags)
+
+	instance, err := service.GetInstance(instanceID)
+	assert.Nil(t, err)
+	assert.Equal(t, "volgroup-other", instance.VolumeGroupID)
+}
+
+func TestUnitVolumeGroupInstance_import(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service := newFakeECloudService()
+	instanceID, _ := service.CreateInstance(ecloudservice.CreateInstanceRequest{VPCID: "vpc-abcdef12"})
+	service.PatchInstance(instanceID, ecloudservice.PatchInstanceRequest{VolumeGroupID: ptr.String("volgroup-abcdef12")})
+
+	t.Run("Attached", func(t *testing.T) {
+		d := resourceVolumeGroupInstance().TestResourceData()
+		d.SetId(instanceID + "/volgroup-abcdef12")
+
+		result, err := resourceVolumeGroupInstanceImport(ctx, d, service)
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, instanceID+".volgroup-abcdef12", d.Id())
+		assert.Equal(t, instanceID, d.Get("instance_id"))
+		assert.Equal(t, "volgroup-abcdef12", d.Get("volume_group_id"))
+	})
+
+	t.Run("NotAttached_ReturnsError", func(t *testing.T) {
+		d := resourceVolumeGroupInstance().TestResourceData()
+		d.SetId(instanceID + "/volgroup-other")
+
+		_, err := resourceVolumeGroupInstanceImport(ctx, d, service)
+		assert.EqualError(t, err, fmt.Sprintf("Instance with ID [%s] is not attached to volume group with ID [volgroup-other]", instanceID))
+	})
+
+	t.Run("InstanceNotFound_ReturnsError", func(t *testing.T) {
+		d := resourceVolumeGroupInstance().TestResourceData()
+		d.SetId("i-00000000/volgroup-abcdef12")
+
+		_, err := resourceVolumeGroupInstanceImport(ctx, d, service)
+		assert.ErrorContains(t, err, "Error retrieving instance with ID [i-00000000]")
+	})
+
+	t.Run("InvalidID_ReturnsError", func(t *testing.T) {
+		d := resourceVolumeGroupInstance().TestResourceData()
+		d.SetId(instanceID + ".volgroup-abcdef12")
+
+		_, err := resourceVolumeGroupInstanceImport(ctx, d, service)
+		assert.EqualError(t, err, fmt.Sprintf("Unexpected format of ID [%s.volgroup-abcdef12], expected <instance_id>/<volume_group_id>", instanceID))
+	})
+}
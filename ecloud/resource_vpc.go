@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +22,12 @@ func resourceVPC() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region_id": {
 				Type:     schema.TypeString,
@@ -66,17 +71,15 @@ func resourceVPCCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	d.SetId(vpcID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    VPCSyncStatusRefreshFunc(service, vpcID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "VPC",
+		ResourceID: vpcID,
+		Sync:       VPCSyncFunc(service, vpcID),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPC with ID [%s] to return sync status of [%s]: %s", vpcID, ecloudservice.SyncStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVPCRead(ctx, d, meta)
@@ -123,17 +126,15 @@ func resourceVPCUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 			return diag.Errorf("Error updating VPC with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    VPCSyncStatusRefreshFunc(service, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPC with ID [%s] to return sync status of [%s]: %s", d.Id(), ecloudservice.SyncStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPC",
+			ResourceID: d.Id(),
+			Sync:       VPCSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -148,41 +149,39 @@ func resourceVPCDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 	})
 	err := service.DeleteVPC(d.Id())
 	if err != nil {
-		return diag.Errorf("Error VPC with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.VPCNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing VPC with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"Deleted"},
-		Refresh:    VPCSyncStatusRefreshFunc(service, d.Id()),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "VPC",
+		ResourceID: d.Id(),
+		Sync:       VPCSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPC with ID [%s] to be deleted: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
 
-// VPCSyncStatusRefreshFunc returns a function with StateRefreshFunc signature for use
-// with StateChangeConf
-func VPCSyncStatusRefreshFunc(service ecloudservice.ECloudService, vpcID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+// VPCSyncFunc returns a ResourceSyncFunc retrieving the sync state of the VPC with given ID
+func VPCSyncFunc(service ecloudservice.ECloudService, vpcID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
 		vpc, err := service.GetVPC(vpcID)
 		if err != nil {
 			if _, ok := err.(*ecloudservice.VPCNotFoundError); ok {
-				return vpc, "Deleted", nil
+				return nil, nil
 			}
-			return nil, "", err
-		}
-
-		if vpc.Sync.Status == ecloudservice.SyncStatusFailed {
-			return nil, "", fmt.Errorf("Failed to create/update VPC - review logs")
+			return nil, err
 		}
 
-		return vpc, vpc.Sync.Status.String(), nil
+		return &vpc.Sync, nil
 	}
 }
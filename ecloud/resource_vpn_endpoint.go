@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceVPNEndpoint() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpn_service_id": {
 				Type:     schema.TypeString,
@@ -72,17 +77,16 @@ func resourceVPNEndpointCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "VPN endpoint",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VPNEndpointSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPN endpoint with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVPNEndpointRead(ctx, d, meta)
@@ -128,17 +132,16 @@ func resourceVPNEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error updating VPNEndpoint with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPN endpoint with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPN endpoint",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VPNEndpointSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -153,20 +156,24 @@ func resourceVPNEndpointDelete(ctx context.Context, d *schema.ResourceData, meta
 	})
 	taskID, err := service.DeleteVPNEndpoint(d.Id())
 	if err != nil {
-		return diag.Errorf("Error VPNEndpoint with ID [%s]: %s", d.Id(), err)
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+		switch err.(type) {
+		case *ecloudservice.VPNEndpointNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing VPN endpoint with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPNEndpoint with ID [%s] to be deleted: %s", d.Id(), err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "VPN endpoint",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VPNEndpointSyncFunc(service, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	// remove floating ip if set
@@ -189,18 +196,33 @@ func resourceVPNEndpointDelete(ctx context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		stateConf = &resource.StateChangeConf{
-			Target:     []string{ecloudservice.TaskStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+		diags = waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "delete",
+			Resource:   "floating IP",
+			ResourceID: fip,
+			TaskID:     taskID,
+			Sync:       FloatingIPSyncFunc(service, fip),
 			Timeout:    d.Timeout(schema.TimeoutDelete),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for floating ip with ID [%s] to be removed: %s", d.Id(), err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
 	return nil
 }
+
+// VPNEndpointSyncFunc returns a ResourceSyncFunc retrieving the sync state of the VPN endpoint with given ID
+func VPNEndpointSyncFunc(service ecloudservice.ECloudService, endpointID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		endpoint, err := service.GetVPNEndpoint(endpointID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VPNEndpointNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &endpoint.Sync, nil
+	}
+}
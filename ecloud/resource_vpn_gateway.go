@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceVPNGateway() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"router_id": {
 				Type:     schema.TypeString,
@@ -64,17 +69,16 @@ func resourceVPNGatewayCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "VPN gateway",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VPNGatewaySyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPN gateway with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVPNGatewayRead(ctx, d, meta)
@@ -121,17 +125,16 @@ func resourceVPNGatewayUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error updating VPN gateway with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPN gateway",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VPNGatewaySyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPN gateway with ID [%s] to be updated: %s", d.Id(), err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -146,21 +149,40 @@ func resourceVPNGatewayDelete(ctx context.Context, d *schema.ResourceData, meta
 	})
 	taskID, err := service.DeleteVPNGateway(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing VPN gateway with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.VPNGatewayNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing VPN gateway with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "VPN gateway",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VPNGatewaySyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPN gateway with ID [%s] to be removed: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// VPNGatewaySyncFunc returns a ResourceSyncFunc retrieving the sync state of the VPN gateway with given ID
+func VPNGatewaySyncFunc(service ecloudservice.ECloudService, gatewayID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		gateway, err := service.GetVPNGateway(gatewayID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VPNGatewayNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &gateway.Sync, nil
+	}
+}
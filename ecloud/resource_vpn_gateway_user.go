@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceVPNGatewayUser() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpn_gateway_id": {
 				Type:     schema.TypeString,
@@ -71,17 +76,16 @@ func resourceVPNGatewayUserCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "VPN gateway user",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VPNGatewayUserSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPN gateway user with ID [%s] to be created: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVPNGatewayUserRead(ctx, d, meta)
@@ -136,17 +140,16 @@ func resourceVPNGatewayUserUpdate(ctx context.Context, d *schema.ResourceData, m
 			return diag.Errorf("Error updating VPN gateway user with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPN gateway user",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VPNGatewayUserSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPN gateway user with ID [%s] to be updated: %s", d.Id(), err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -161,21 +164,40 @@ func resourceVPNGatewayUserDelete(ctx context.Context, d *schema.ResourceData, m
 	})
 	taskID, err := service.DeleteVPNGatewayUser(d.Id())
 	if err != nil {
-		return diag.Errorf("Error removing VPN gateway user with ID [%s]: %s", d.Id(), err)
+		switch err.(type) {
+		case *ecloudservice.VPNGatewayUserNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing VPN gateway user with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "VPN gateway user",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VPNGatewayUserSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPN gateway user with ID [%s] to be removed: %s", d.Id(), err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// VPNGatewayUserSyncFunc returns a ResourceSyncFunc retrieving the sync state of the VPN gateway user with given ID
+func VPNGatewayUserSyncFunc(service ecloudservice.ECloudService, userID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		user, err := service.GetVPNGatewayUser(userID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VPNGatewayUserNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &user.Sync, nil
+	}
+}
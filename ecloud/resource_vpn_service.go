@@ -8,7 +8,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +21,12 @@ func resourceVPNService() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"router_id": {
 				Type:     schema.TypeString,
@@ -53,17 +58,16 @@ func resourceVPNServiceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "VPN service",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VPNServiceSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPN service with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return resourceVPNServiceRead(ctx, d, meta)
@@ -108,17 +112,16 @@ func resourceVPNServiceUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error updating VPNService with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPN service with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPN service",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VPNServiceSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -133,21 +136,40 @@ func resourceVPNServiceDelete(ctx context.Context, d *schema.ResourceData, meta
 	})
 	taskID, err := service.DeleteVPNService(d.Id())
 	if err != nil {
-		return diag.Errorf("Error VPNService with ID [%s]: %s", d.Id(), err)
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+		switch err.(type) {
+		case *ecloudservice.VPNServiceNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing VPN service with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPNService with ID [%s] to be deleted: %s", d.Id(), err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "VPN service",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VPNServiceSyncFunc(service, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// VPNServiceSyncFunc returns a ResourceSyncFunc retrieving the sync state of the VPN service with given ID
+func VPNServiceSyncFunc(service ecloudservice.ECloudService, serviceID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		vpnService, err := service.GetVPNService(serviceID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VPNServiceNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &vpnService.Sync, nil
+	}
+}
@@ -9,7 +9,6 @@ import (
 	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +22,12 @@ func resourceVPNSession() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpn_service_id": {
 				Type:     schema.TypeString,
@@ -47,12 +52,14 @@ func resourceVPNSession() *schema.Resource {
 				Computed: true,
 			},
 			"remote_networks": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateCIDRList,
 			},
 			"local_networks": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateCIDRList,
 			},
 			"psk": {
 				Type:      schema.TypeString,
@@ -86,17 +93,16 @@ func resourceVPNSessionCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(taskRef.ResourceID)
 
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "create",
+		Resource:   "VPN session",
+		ResourceID: d.Id(),
+		TaskID:     taskRef.TaskID,
+		Sync:       VPNSessionSyncFunc(service, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPN session with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	if d.HasChange("psk") {
@@ -110,17 +116,16 @@ func resourceVPNSessionCreate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error creating VPN session pre-shared key: %s", err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPN session",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VPNSessionSyncFunc(service, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPN session with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -157,7 +162,13 @@ func resourceVPNSessionRead(ctx context.Context, d *schema.ResourceData, meta in
 	})
 	psk, err := service.GetVPNSessionPreSharedKey(d.Id())
 	if err != nil {
-		return diag.Errorf("Error retrieving VPN service pre-shared key: %s", err)
+		switch err.(type) {
+		case *ecloudservice.VPNSessionNotFoundError:
+			d.SetId("")
+			return nil
+		default:
+			return diag.Errorf("Error retrieving VPN session pre-shared key: %s", err)
+		}
 	}
 	d.Set("psk", psk.PSK)
 
@@ -199,17 +210,16 @@ func resourceVPNSessionUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error updating VPNSession with ID [%s]: %s", d.Id(), err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPN session with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPN session",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VPNSessionSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -224,17 +234,16 @@ func resourceVPNSessionUpdate(ctx context.Context, d *schema.ResourceData, meta
 			return diag.Errorf("Error creating VPN session pre-shared key: %s", err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Target:     []string{ecloudservice.SyncStatusComplete.String()},
-			Refresh:    TaskStatusRefreshFunc(ctx, service, taskRef.TaskID),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("Error waiting for VPN session with ID [%s] to return task status of [%s]: %s", d.Id(), ecloudservice.TaskStatusComplete, err)
+		diags := waitForResourceOperation(ctx, service, resourceOperation{
+			Operation:  "update",
+			Resource:   "VPN session",
+			ResourceID: d.Id(),
+			TaskID:     taskRef.TaskID,
+			Sync:       VPNSessionSyncFunc(service, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+		})
+		if diags.HasError() {
+			return diags
 		}
 	}
 
@@ -249,21 +258,40 @@ func resourceVPNSessionDelete(ctx context.Context, d *schema.ResourceData, meta
 	})
 	taskID, err := service.DeleteVPNSession(d.Id())
 	if err != nil {
-		return diag.Errorf("Error VPNSession with ID [%s]: %s", d.Id(), err)
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{ecloudservice.SyncStatusComplete.String()},
-		Refresh:    TaskStatusRefreshFunc(ctx, service, taskID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+		switch err.(type) {
+		case *ecloudservice.VPNSessionNotFoundError:
+			return nil
+		default:
+			return diag.Errorf("Error removing VPN session with ID [%s]: %s", d.Id(), err)
+		}
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("Error waiting for VPNSession with ID [%s] to be deleted: %s", d.Id(), err)
+	diags := waitForResourceOperation(ctx, service, resourceOperation{
+		Operation:  "delete",
+		Resource:   "VPN session",
+		ResourceID: d.Id(),
+		TaskID:     taskID,
+		Sync:       VPNSessionSyncFunc(service, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+	})
+	if diags.HasError() {
+		return diags
 	}
 
 	return nil
 }
+
+// VPNSessionSyncFunc returns a ResourceSyncFunc retrieving the sync state of the VPN session with given ID
+func VPNSessionSyncFunc(service ecloudservice.ECloudService, sessionID string) ResourceSyncFunc {
+	return func() (*ecloudservice.ResourceSync, error) {
+		session, err := service.GetVPNSession(sessionID)
+		if err != nil {
+			if _, ok := err.(*ecloudservice.VPNSessionNotFoundError); ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return &session.Sync, nil
+	}
+}
@@ -0,0 +1,160 @@
+package ecloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/poll"
+)
+
+// ResourceSyncFunc retrieves the sync state of a resource, returning nil if the resource no
+// longer exists
+type ResourceSyncFunc func() (*ecloudservice.ResourceSync, error)
+
+// resourceOperation describes an asynchronous create, update or delete of a resource
+type resourceOperation struct {
+	// Operation is the action being performed, e.g. "create" or "assign"
+	Operation string
+	// Resource is the human readable type of the resource, e.g. "firewall rule"
+	Resource   string
+	ResourceID string
+	// TaskID is the task returned by the API for the operation. When empty, Sync is polled
+	// until the resource has synced (or no longer exists, for deletes)
+	TaskID string
+	// Sync retrieves the sync state of the resource, or of the parent resource which is synced
+	// on its behalf (see SyncResource). It is used to explain failures
+	Sync ResourceSyncFunc
+	// SyncResource describes the resource returned by Sync where this differs from the
+	// resource being operated on, e.g. "firewall policy [fwp-abcdef12]"
+	SyncResource string
+	Timeout      time.Duration
+}
+
+func (op resourceOperation) isDelete() bool {
+	return op.Operation == "delete"
+}
+
+// waitForResourceOperation waits for op to complete. On failure, the returned diagnostic
+// includes the details of the task and the sync state of the resource
+func waitForResourceOperation(ctx context.Context, service ecloudservice.ECloudService, op resourceOperation) diag.Diagnostics {
+	if op.TaskID == "" && op.Sync == nil {
+		return nil
+	}
+
+	target := ecloudservice.TaskStatusComplete.String()
+	refreshFunc := TaskStatusRefreshFunc(ctx, service, op.TaskID)
+	if op.TaskID == "" {
+		target = ecloudservice.SyncStatusComplete.String()
+		if op.isDelete() {
+			target = "Deleted"
+		}
+		refreshFunc = resourceSyncRefreshFunc(op.Sync, op.isDelete())
+	}
+
+	tflog.Debug(ctx, "Waiting for resource operation", map[string]interface{}{
+		"operation":   op.Operation,
+		"resource":    op.Resource,
+		"resource_id": op.ResourceID,
+		"task_id":     op.TaskID,
+	})
+
+	_, err := waitForResourceState(ctx, service, poll.Wait{
+		Target:   target,
+		Refresh:  poll.RefreshFunc(refreshFunc),
+		Timeout:  op.Timeout,
+		Deleting: op.TaskID == "" && op.isDelete(),
+	})
+	if err == nil {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error waiting to %s %s with ID [%s]", op.Operation, op.Resource, op.ResourceID),
+			Detail:   describeResourceOperationFailure(service, op, err),
+		},
+	}
+}
+
+// timeoutRemaining returns the time remaining before deadline, for a sequence of operations sharing
+// a single timeout. Once the deadline has passed the smallest positive timeout is returned, as a
+// zero timeout disables the poll timeout
+func timeoutRemaining(deadline time.Time) time.Duration {
+	if remaining := time.Until(deadline); remaining > 0 {
+		return remaining
+	}
+	return time.Nanosecond
+}
+
+// waitForResourceState waits for wait.Target using the poller configured for the provider.
+// Concurrency slots (see max_concurrent_requests) are only held by each refresh request, not between polls
+func waitForResourceState(ctx context.Context, service ecloudservice.ECloudService, wait poll.Wait) (interface{}, error) {
+	return getPoller(service).WaitForState(ctx, wait)
+}
+
+// describeResourceOperationFailure retrieves the current state of the task and resource for op,
+// for inclusion in a diagnostic
+func describeResourceOperationFailure(service ecloudservice.ECloudService, op resourceOperation, err error) string {
+	details := []string{err.Error()}
+
+	if op.TaskID != "" {
+		task, taskErr := service.GetTask(op.TaskID)
+		if taskErr != nil {
+			details = append(details, fmt.Sprintf("Unable to retrieve task [%s]: %s", op.TaskID, taskErr))
+		} else {
+			details = append(details, fmt.Sprintf("Task [%s] (%s) for resource [%s] has status [%s], created at [%s], last updated at [%s]",
+				task.ID, task.Name, task.ResourceID, task.Status, task.CreatedAt, task.UpdatedAt))
+		}
+	}
+
+	if op.Sync != nil {
+		syncResource := op.SyncResource
+		if syncResource == "" {
+			syncResource = fmt.Sprintf("%s [%s]", op.Resource, op.ResourceID)
+		}
+
+		sync, syncErr := op.Sync()
+		switch {
+		case syncErr != nil:
+			details = append(details, fmt.Sprintf("Unable to retrieve sync status of %s: %s", syncResource, syncErr))
+		case sync == nil:
+			details = append(details, fmt.Sprintf("The %s no longer exists", syncResource))
+		default:
+			details = append(details, fmt.Sprintf("The %s has sync status [%s] for its last [%s] operation", syncResource, sync.Status, sync.Type))
+		}
+	}
+
+	return strings.Join(details, "\n\n")
+}
+
+// resourceSyncRefreshFunc returns a function with StateRefreshFunc signature for use with
+// waitForResourceState, reporting the sync status of a resource. When deleting, a resource which
+// no longer exists is reported as "Deleted"
+func resourceSyncRefreshFunc(syncFunc ResourceSyncFunc, deleting bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		sync, err := syncFunc()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if sync == nil {
+			if deleting {
+				return "", "Deleted", nil
+			}
+			return nil, "", fmt.Errorf("Resource no longer exists")
+		}
+
+		if sync.Status == ecloudservice.SyncStatusFailed {
+			return nil, "", fmt.Errorf("Resource has sync status of %s", sync.Status)
+		}
+
+		return *sync, sync.Status.String(), nil
+	}
+}
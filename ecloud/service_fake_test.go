@@ -0,0 +1,1596 @@
+package ecloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/poll"
+)
+
+// fakeECloudService is an in-memory implementation of ecloudservice.ECloudService for use
+// in unit tests. Only the methods required by the resources under unit test are implemented;
+// any other call falls through to the embedded nil interface and panics, which surfaces
+// untested API usage immediately.
+type fakeECloudService struct {
+	ecloudservice.ECloudService
+
+	mu  sync.Mutex
+	seq int
+
+	// SyncSteps is the number of reads for which a created/updated/deleted resource reports a
+	// sync status of in-progress before completing
+	SyncSteps int
+	// TaskSteps is the number of reads for which a task reports a status of in-progress
+	// before completing
+	TaskSteps int
+
+	// FailShutdown causes graceful shutdown tasks to fail, leaving the instance online, as
+	// when the guest doesn't respond to the shutdown request
+	FailShutdown bool
+	// HangShutdown causes graceful shutdown tasks to remain in progress, leaving the instance
+	// online, as when the guest is slow to respond to the shutdown request
+	HangShutdown bool
+	// FailScripts causes script tasks to fail, as when the script exits with a non-zero code
+	FailScripts bool
+	// StayOffline causes instances to remain offline once powered on, as when the guest fails to boot
+	StayOffline bool
+
+	// failed holds resource and task IDs whose sync and tasks should report a status of failed
+	failed map[string]bool
+	// parents maps resource IDs to the ID of the parent resource synced on their behalf (e.g.
+	// firewall rule to firewall policy), so that tasks fail when their parent has failed
+	parents map[string]string
+
+	vpcs                map[string]*fakeRecord[ecloudservice.VPC]
+	routers             map[string]*fakeRecord[ecloudservice.Router]
+	networks            map[string]*fakeRecord[ecloudservice.Network]
+	firewallPolicies    map[string]*fakeRecord[ecloudservice.FirewallPolicy]
+	firewallRules       map[string]*fakeRecord[ecloudservice.FirewallRule]
+	firewallRulePorts   map[string]*fakeRecord[ecloudservice.FirewallRulePort]
+	affinityRules       map[string]*fakeRecord[ecloudservice.AffinityRule]
+	affinityRuleMembers map[string]*fakeRecord[ecloudservice.AffinityRuleMember]
+	instances           map[string]*fakeRecord[ecloudservice.Instance]
+	volumes             map[string]*fakeRecord[ecloudservice.Volume]
+	floatingIPs         map[string]*fakeRecord[ecloudservice.FloatingIP]
+	nics                map[string]*fakeRecord[ecloudservice.NIC]
+	tasks               map[string]*fakeRecord[ecloudservice.Task]
+
+	// hostGroups, hostSpecs, resourceTiers, iopsTiers and images are read only, and are
+	// populated using the Add* methods
+	images        map[string]*fakeRecord[ecloudservice.Image]
+	hostGroups    map[string]*fakeRecord[ecloudservice.HostGroup]
+	hostSpecs     map[string]*fakeRecord[ecloudservice.HostSpec]
+	resourceTiers map[string]*fakeRecord[ecloudservice.ResourceTier]
+	// iopsTiers maps availability zone IDs to the IOPS tiers available within them
+	iopsTiers map[string][]ecloudservice.IOPSTier
+
+	// instanceVolumes maps instance IDs to the IDs of their attached volumes
+	instanceVolumes map[string][]string
+	// instanceScripts maps instance IDs to the script requests executed on them, in order
+	instanceScripts map[string][]ecloudservice.ExecuteInstanceScriptRequest
+	// instanceCredentials maps instance IDs to their credentials, populated using
+	// AddInstanceCredential
+	instanceCredentials map[string][]ecloudservice.Credential
+}
+
+// fakeRecord wraps a stored object along with its simulated sync state
+type fakeRecord[T any] struct {
+	value    T
+	pending  int
+	deleting bool
+}
+
+func newFakeECloudService() *fakeECloudService {
+	return &fakeECloudService{
+		failed:              make(map[string]bool),
+		parents:             make(map[string]string),
+		vpcs:                make(map[string]*fakeRecord[ecloudservice.VPC]),
+		routers:             make(map[string]*fakeRecord[ecloudservice.Router]),
+		networks:            make(map[string]*fakeRecord[ecloudservice.Network]),
+		firewallPolicies:    make(map[string]*fakeRecord[ecloudservice.FirewallPolicy]),
+		firewallRules:       make(map[string]*fakeRecord[ecloudservice.FirewallRule]),
+		firewallRulePorts:   make(map[string]*fakeRecord[ecloudservice.FirewallRulePort]),
+		affinityRules:       make(map[string]*fakeRecord[ecloudservice.AffinityRule]),
+		affinityRuleMembers: make(map[string]*fakeRecord[ecloudservice.AffinityRuleMember]),
+		instances:           make(map[string]*fakeRecord[ecloudservice.Instance]),
+		volumes:             make(map[string]*fakeRecord[ecloudservice.Volume]),
+		floatingIPs:         make(map[string]*fakeRecord[ecloudservice.FloatingIP]),
+		nics:                make(map[string]*fakeRecord[ecloudservice.NIC]),
+		tasks:               make(map[string]*fakeRecord[ecloudservice.Task]),
+		instanceVolumes:     make(map[string][]string),
+		instanceScripts:     make(map[string][]ecloudservice.ExecuteInstanceScriptRequest),
+		instanceCredentials: make(map[string][]ecloudservice.Credential),
+		images:              make(map[string]*fakeRecord[ecloudservice.Image]),
+		hostGroups:          make(map[string]*fakeRecord[ecloudservice.HostGroup]),
+		hostSpecs:           make(map[string]*fakeRecord[ecloudservice.HostSpec]),
+		resourceTiers:       make(map[string]*fakeRecord[ecloudservice.ResourceTier]),
+		iopsTiers:           make(map[string][]ecloudservice.IOPSTier),
+	}
+}
+
+// Poller returns a poller without delays, so that unit tests don't wait between polls
+func (f *fakeECloudService) Poller() *poll.Poller {
+	return poll.NewPoller(poll.Config{})
+}
+
+// testUnitProviderFactories returns provider factories which configure the provider with
+// the given service rather than connecting to the API
+func testUnitProviderFactories(service ecloudservice.ECloudService) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"ecloud": func() (*schema.Provider, error) {
+			p := Provider()
+			p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				return service, nil
+			}
+			return p, nil
+		},
+	}
+}
+
+// Fail causes the sync status and any subsequent tasks for resource with given ID to
+// report a status of failed
+func (f *fakeECloudService) Fail(resourceID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed[resourceID] = true
+}
+
+// Remove deletes resource with given ID from the store immediately, simulating the
+// resource being removed outside of Terraform
+func (f *fakeECloudService) Remove(resourceID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.vpcs, resourceID)
+	delete(f.routers, resourceID)
+	delete(f.networks, resourceID)
+	delete(f.firewallPolicies, resourceID)
+	delete(f.firewallRules, resourceID)
+	delete(f.affinityRules, resourceID)
+	delete(f.affinityRuleMembers, resourceID)
+	delete(f.instances, resourceID)
+	delete(f.volumes, resourceID)
+	delete(f.floatingIPs, resourceID)
+	delete(f.nics, resourceID)
+}
+
+func (f *fakeECloudService) newID(prefix string) string {
+	f.seq++
+	return fmt.Sprintf("%s-%08x", prefix, f.seq)
+}
+
+func (f *fakeECloudService) newTask(resourceID string, name string) string {
+	task := ecloudservice.Task{
+		ID:         f.newID("task"),
+		ResourceID: resourceID,
+		Name:       name,
+		Status:     ecloudservice.TaskStatusInProgress,
+	}
+	f.tasks[task.ID] = &fakeRecord[ecloudservice.Task]{value: task, pending: f.TaskSteps}
+	return task.ID
+}
+
+// fakeTouch marks record as having been mutated, returning it to an in-progress sync state
+func fakeTouch[T any](f *fakeECloudService, r *fakeRecord[T]) {
+	r.pending = f.SyncSteps
+}
+
+// fakeSyncStatus advances the simulated sync of record r and returns its current status
+func fakeSyncStatus[T any](f *fakeECloudService, id string, r *fakeRecord[T]) ecloudservice.ResourceSync {
+	syncType := ecloudservice.SyncTypeUpdate
+	if r.deleting {
+		syncType = ecloudservice.SyncTypeDelete
+	}
+	if f.failed[id] {
+		return ecloudservice.ResourceSync{Status: ecloudservice.SyncStatusFailed, Type: syncType}
+	}
+	if r.pending > 0 {
+		r.pending--
+		return ecloudservice.ResourceSync{Status: ecloudservice.SyncStatusInProgress, Type: syncType}
+	}
+	return ecloudservice.ResourceSync{Status: ecloudservice.SyncStatusComplete, Type: syncType}
+}
+
+// fakeGet returns the record with given ID, removing records which have finished deleting
+func fakeGet[T any](f *fakeECloudService, m map[string]*fakeRecord[T], id string) (*fakeRecord[T], bool) {
+	r, ok := m[id]
+	if !ok {
+		return nil, false
+	}
+	if r.deleting && r.pending < 1 && !f.failed[id] {
+		delete(m, id)
+		return nil, false
+	}
+	return r, true
+}
+
+// fakeMarkDeleted removes the record with given ID, or flags it as deleting if sync steps are
+// configured
+func fakeMarkDeleted[T any](f *fakeECloudService, m map[string]*fakeRecord[T], id string) {
+	r := m[id]
+	if f.SyncSteps < 1 {
+		delete(m, id)
+		return
+	}
+	r.deleting = true
+	r.pending = f.SyncSteps
+}
+
+// fakeList returns the values of map m which match given parameters, ordered by ID
+func fakeList[T any](f *fakeECloudService, m map[string]*fakeRecord[T], parameters connection.APIRequestParameters) []T {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var values []T
+	for _, id := range ids {
+		r, ok := fakeGet(f, m, id)
+		if !ok {
+			continue
+		}
+		if fakeMatchesFilters(r.value, parameters.Filtering) {
+			values = append(values, r.value)
+		}
+	}
+	return values
+}
+
+// fakePaginated returns the page of values requested by parameters, for use by the *Paginated
+// methods
+func fakePaginated[T any](values []T, parameters connection.APIRequestParameters, getFunc connection.PaginatedGetFunc[T]) *connection.Paginated[T] {
+	perPage := parameters.Pagination.PerPage
+	if perPage < 1 {
+		perPage = len(values) + 1
+	}
+	page := parameters.Pagination.Page
+	if page < 1 {
+		page = 1
+	}
+
+	totalPages := (len(values) + perPage - 1) / perPage
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	start := min((page-1)*perPage, len(values))
+	end := min(start+perPage, len(values))
+
+	body := &connection.APIResponseBodyData[[]T]{Data: values[start:end]}
+	body.Metadata.Pagination = connection.APIResponseMetadataPagination{
+		Total:      len(values),
+		Count:      end - start,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+	}
+	return connection.NewPaginated(body, parameters, getFunc)
+}
+
+// fakeMatchesFilters returns true if JSON representation of v matches all equality filters
+func fakeMatchesFilters(v interface{}, filters []connection.APIRequestFiltering) bool {
+	if len(filters) < 1 {
+		return true
+	}
+
+	b, _ := json.Marshal(v)
+	var m map[string]interface{}
+	_ = json.Unmarshal(b, &m)
+
+	for _, filter := range filters {
+		actual := fmt.Sprintf("%v", m[filter.Property])
+		matched := false
+		for _, value := range filter.Value {
+			if strings.EqualFold(actual, value) {
+				matched = true
+				break
+			}
+		}
+		if matched != (filter.Operator != connection.NEQOperator && filter.Operator != connection.NINOperator) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Tasks
+
+func (f *fakeECloudService) GetTask(taskID string) (ecloudservice.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := f.tasks[taskID]
+	if !ok {
+		return ecloudservice.Task{}, &ecloudservice.TaskNotFoundError{ID: taskID}
+	}
+
+	switch {
+	case f.failed[taskID], f.failed[r.value.ResourceID], f.failed[f.parents[r.value.ResourceID]]:
+		r.value.Status = ecloudservice.TaskStatusFailed
+	case r.pending > 0:
+		r.pending--
+		r.value.Status = ecloudservice.TaskStatusInProgress
+	default:
+		r.value.Status = ecloudservice.TaskStatusComplete
+	}
+
+	return r.value, nil
+}
+
+// VPCs
+
+func (f *fakeECloudService) GetVPC(vpcID string) (ecloudservice.VPC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.vpcs, vpcID)
+	if !ok {
+		return ecloudservice.VPC{}, &ecloudservice.VPCNotFoundError{ID: vpcID}
+	}
+	r.value.Sync = fakeSyncStatus(f, vpcID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetVPCs(parameters connection.APIRequestParameters) ([]ecloudservice.VPC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.vpcs, parameters), nil
+}
+
+func (f *fakeECloudService) CreateVPC(req ecloudservice.CreateVPCRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc := ecloudservice.VPC{
+		ID:       f.newID("vpc"),
+		Name:     req.Name,
+		RegionID: req.RegionID,
+	}
+	if req.AdvancedNetworking != nil {
+		vpc.AdvancedNetworking = *req.AdvancedNetworking
+	}
+	f.vpcs[vpc.ID] = &fakeRecord[ecloudservice.VPC]{value: vpc, pending: f.SyncSteps}
+	return vpc.ID, nil
+}
+
+func (f *fakeECloudService) PatchVPC(vpcID string, req ecloudservice.PatchVPCRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.vpcs, vpcID)
+	if !ok {
+		return &ecloudservice.VPCNotFoundError{ID: vpcID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	fakeTouch(f, r)
+	return nil
+}
+
+func (f *fakeECloudService) DeleteVPC(vpcID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.vpcs, vpcID); !ok {
+		return &ecloudservice.VPCNotFoundError{ID: vpcID}
+	}
+	fakeMarkDeleted(f, f.vpcs, vpcID)
+	return nil
+}
+
+// Routers
+
+func (f *fakeECloudService) GetRouter(routerID string) (ecloudservice.Router, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.routers, routerID)
+	if !ok {
+		return ecloudservice.Router{}, &ecloudservice.RouterNotFoundError{ID: routerID}
+	}
+	r.value.Sync = fakeSyncStatus(f, routerID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetRouters(parameters connection.APIRequestParameters) ([]ecloudservice.Router, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.routers, parameters), nil
+}
+
+func (f *fakeECloudService) CreateRouter(req ecloudservice.CreateRouterRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	router := ecloudservice.Router{
+		ID:                 f.newID("rtr"),
+		Name:               req.Name,
+		VPCID:              req.VPCID,
+		AvailabilityZoneID: req.AvailabilityZoneID,
+		RouterThroughputID: req.RouterThroughputID,
+	}
+	f.routers[router.ID] = &fakeRecord[ecloudservice.Router]{value: router, pending: f.SyncSteps}
+	return router.ID, nil
+}
+
+func (f *fakeECloudService) PatchRouter(routerID string, req ecloudservice.PatchRouterRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.routers, routerID)
+	if !ok {
+		return &ecloudservice.RouterNotFoundError{ID: routerID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	if req.RouterThroughputID != "" {
+		r.value.RouterThroughputID = req.RouterThroughputID
+	}
+	fakeTouch(f, r)
+	return nil
+}
+
+func (f *fakeECloudService) DeleteRouter(routerID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.routers, routerID); !ok {
+		return &ecloudservice.RouterNotFoundError{ID: routerID}
+	}
+	fakeMarkDeleted(f, f.routers, routerID)
+	return nil
+}
+
+// Networks
+
+func (f *fakeECloudService) GetNetwork(networkID string) (ecloudservice.Network, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.networks, networkID)
+	if !ok {
+		return ecloudservice.Network{}, &ecloudservice.NetworkNotFoundError{ID: networkID}
+	}
+	r.value.Sync = fakeSyncStatus(f, networkID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetNetworks(parameters connection.APIRequestParameters) ([]ecloudservice.Network, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.networks, parameters), nil
+}
+
+func (f *fakeECloudService) GetNetworksPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.Network], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakePaginated(fakeList(f, f.networks, parameters), parameters, f.GetNetworksPaginated), nil
+}
+
+func (f *fakeECloudService) CreateNetwork(req ecloudservice.CreateNetworkRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	network := ecloudservice.Network{
+		ID:       f.newID("net"),
+		Name:     req.Name,
+		RouterID: req.RouterID,
+		Subnet:   req.Subnet,
+	}
+	f.networks[network.ID] = &fakeRecord[ecloudservice.Network]{value: network, pending: f.SyncSteps}
+	return network.ID, nil
+}
+
+func (f *fakeECloudService) PatchNetwork(networkID string, req ecloudservice.PatchNetworkRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.networks, networkID)
+	if !ok {
+		return &ecloudservice.NetworkNotFoundError{ID: networkID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	fakeTouch(f, r)
+	return nil
+}
+
+func (f *fakeECloudService) DeleteNetwork(networkID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.networks, networkID); !ok {
+		return &ecloudservice.NetworkNotFoundError{ID: networkID}
+	}
+	fakeMarkDeleted(f, f.networks, networkID)
+	return nil
+}
+
+// Firewall policies
+
+func (f *fakeECloudService) GetFirewallPolicy(policyID string) (ecloudservice.FirewallPolicy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.firewallPolicies, policyID)
+	if !ok {
+		return ecloudservice.FirewallPolicy{}, &ecloudservice.FirewallPolicyNotFoundError{ID: policyID}
+	}
+	r.value.Sync = fakeSyncStatus(f, policyID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetFirewallPolicies(parameters connection.APIRequestParameters) ([]ecloudservice.FirewallPolicy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.firewallPolicies, parameters), nil
+}
+
+func (f *fakeECloudService) CreateFirewallPolicy(req ecloudservice.CreateFirewallPolicyRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	policy := ecloudservice.FirewallPolicy{
+		ID:       f.newID("fwp"),
+		Name:     req.Name,
+		RouterID: req.RouterID,
+		Sequence: req.Sequence,
+	}
+	f.firewallPolicies[policy.ID] = &fakeRecord[ecloudservice.FirewallPolicy]{value: policy, pending: f.SyncSteps}
+	return ecloudservice.TaskReference{TaskID: f.newTask(policy.ID, "firewall_policy_create"), ResourceID: policy.ID}, nil
+}
+
+func (f *fakeECloudService) PatchFirewallPolicy(policyID string, req ecloudservice.PatchFirewallPolicyRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.firewallPolicies, policyID)
+	if !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.FirewallPolicyNotFoundError{ID: policyID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	if req.Sequence != nil {
+		r.value.Sequence = *req.Sequence
+	}
+	fakeTouch(f, r)
+	return ecloudservice.TaskReference{TaskID: f.newTask(policyID, "firewall_policy_update"), ResourceID: policyID}, nil
+}
+
+func (f *fakeECloudService) DeleteFirewallPolicy(policyID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.firewallPolicies, policyID); !ok {
+		return "", &ecloudservice.FirewallPolicyNotFoundError{ID: policyID}
+	}
+	fakeMarkDeleted(f, f.firewallPolicies, policyID)
+	return f.newTask(policyID, "firewall_policy_delete"), nil
+}
+
+// Firewall rules
+
+func (f *fakeECloudService) GetFirewallRule(ruleID string) (ecloudservice.FirewallRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.firewallRules, ruleID)
+	if !ok {
+		return ecloudservice.FirewallRule{}, &ecloudservice.FirewallRuleNotFoundError{ID: ruleID}
+	}
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetFirewallRules(parameters connection.APIRequestParameters) ([]ecloudservice.FirewallRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.firewallRules, parameters), nil
+}
+
+func (f *fakeECloudService) GetFirewallRulesPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.FirewallRule], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakePaginated(fakeList(f, f.firewallRules, parameters), parameters, f.GetFirewallRulesPaginated), nil
+}
+
+func (f *fakeECloudService) GetFirewallRuleFirewallRulePorts(ruleID string, parameters connection.APIRequestParameters) ([]ecloudservice.FirewallRulePort, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.firewallRules, ruleID); !ok {
+		return nil, &ecloudservice.FirewallRuleNotFoundError{ID: ruleID}
+	}
+
+	var ports []ecloudservice.FirewallRulePort
+	for _, port := range fakeList(f, f.firewallRulePorts, parameters) {
+		if port.FirewallRuleID == ruleID {
+			ports = append(ports, port)
+		}
+	}
+	return ports, nil
+}
+
+func (f *fakeECloudService) CreateFirewallRule(req ecloudservice.CreateFirewallRuleRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	policy, ok := fakeGet(f, f.firewallPolicies, req.FirewallPolicyID)
+	if !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.FirewallPolicyNotFoundError{ID: req.FirewallPolicyID}
+	}
+
+	rule := ecloudservice.FirewallRule{
+		ID:               f.newID("fwr"),
+		Name:             req.Name,
+		FirewallPolicyID: req.FirewallPolicyID,
+		Sequence:         req.Sequence,
+		Source:           req.Source,
+		Destination:      req.Destination,
+		Action:           req.Action,
+		Direction:        req.Direction,
+		Enabled:          req.Enabled,
+	}
+	f.firewallRules[rule.ID] = &fakeRecord[ecloudservice.FirewallRule]{value: rule}
+	f.parents[rule.ID] = req.FirewallPolicyID
+	for _, portReq := range req.Ports {
+		f.createFirewallRulePort(rule.ID, portReq.Protocol, portReq.Source, portReq.Destination)
+	}
+	fakeTouch(f, policy)
+
+	return ecloudservice.TaskReference{TaskID: f.newTask(rule.ID, "firewall_rule_create"), ResourceID: rule.ID}, nil
+}
+
+func (f *fakeECloudService) createFirewallRulePort(ruleID string, protocol ecloudservice.FirewallRulePortProtocol, source string, destination string) {
+	port := ecloudservice.FirewallRulePort{
+		ID:             f.newID("fwrp"),
+		FirewallRuleID: ruleID,
+		Protocol:       protocol,
+		Source:         source,
+		Destination:    destination,
+	}
+	f.firewallRulePorts[port.ID] = &fakeRecord[ecloudservice.FirewallRulePort]{value: port}
+}
+
+func (f *fakeECloudService) PatchFirewallRule(ruleID string, req ecloudservice.PatchFirewallRuleRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.firewallRules, ruleID)
+	if !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.FirewallRuleNotFoundError{ID: ruleID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	if req.Sequence != nil {
+		r.value.Sequence = *req.Sequence
+	}
+	if req.Source != "" {
+		r.value.Source = req.Source
+	}
+	if req.Destination != "" {
+		r.value.Destination = req.Destination
+	}
+	if req.Action != "" {
+		r.value.Action = req.Action
+	}
+	if req.Direction != "" {
+		r.value.Direction = req.Direction
+	}
+	if req.Enabled != nil {
+		r.value.Enabled = *req.Enabled
+	}
+	if req.Ports != nil {
+		for id, port := range f.firewallRulePorts {
+			if port.value.FirewallRuleID == ruleID {
+				delete(f.firewallRulePorts, id)
+			}
+		}
+		for _, portReq := range req.Ports {
+			f.createFirewallRulePort(ruleID, portReq.Protocol, portReq.Source, portReq.Destination)
+		}
+	}
+	if policy, ok := fakeGet(f, f.firewallPolicies, r.value.FirewallPolicyID); ok {
+		fakeTouch(f, policy)
+	}
+
+	return ecloudservice.TaskReference{TaskID: f.newTask(ruleID, "firewall_rule_update"), ResourceID: ruleID}, nil
+}
+
+func (f *fakeECloudService) DeleteFirewallRule(ruleID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.firewallRules, ruleID)
+	if !ok {
+		return "", &ecloudservice.FirewallRuleNotFoundError{ID: ruleID}
+	}
+	delete(f.firewallRules, ruleID)
+	for id, port := range f.firewallRulePorts {
+		if port.value.FirewallRuleID == ruleID {
+			delete(f.firewallRulePorts, id)
+		}
+	}
+	if policy, ok := fakeGet(f, f.firewallPolicies, r.value.FirewallPolicyID); ok {
+		fakeTouch(f, policy)
+	}
+
+	return f.newTask(ruleID, "firewall_rule_delete"), nil
+}
+
+// Affinity rules
+
+func (f *fakeECloudService) GetAffinityRule(ruleID string) (ecloudservice.AffinityRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.affinityRules, ruleID)
+	if !ok {
+		return ecloudservice.AffinityRule{}, &ecloudservice.AffinityRuleNotFoundError{ID: ruleID}
+	}
+	r.value.Sync = fakeSyncStatus(f, ruleID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetAffinityRules(parameters connection.APIRequestParameters) ([]ecloudservice.AffinityRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.affinityRules, parameters), nil
+}
+
+func (f *fakeECloudService) CreateAffinityRule(req ecloudservice.CreateAffinityRuleRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rule := ecloudservice.AffinityRule{
+		ID:                 f.newID("ar"),
+		Name:               req.Name,
+		VPCID:              req.VPCID,
+		AvailabilityZoneID: req.AvailabilityZoneID,
+		Type:               req.Type,
+	}
+	f.affinityRules[rule.ID] = &fakeRecord[ecloudservice.AffinityRule]{value: rule, pending: f.SyncSteps}
+	return ecloudservice.TaskReference{TaskID: f.newTask(rule.ID, "affinity_rule_create"), ResourceID: rule.ID}, nil
+}
+
+func (f *fakeECloudService) PatchAffinityRule(ruleID string, req ecloudservice.PatchAffinityRuleRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.affinityRules, ruleID)
+	if !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.AffinityRuleNotFoundError{ID: ruleID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	fakeTouch(f, r)
+	return ecloudservice.TaskReference{TaskID: f.newTask(ruleID, "affinity_rule_update"), ResourceID: ruleID}, nil
+}
+
+func (f *fakeECloudService) DeleteAffinityRule(ruleID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.affinityRules, ruleID); !ok {
+		return "", &ecloudservice.AffinityRuleNotFoundError{ID: ruleID}
+	}
+	delete(f.affinityRules, ruleID)
+	return f.newTask(ruleID, "affinity_rule_delete"), nil
+}
+
+func (f *fakeECloudService) GetAffinityRuleMembers(ruleID string, parameters connection.APIRequestParameters) ([]ecloudservice.AffinityRuleMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.affinityRules, ruleID); !ok {
+		return nil, &ecloudservice.AffinityRuleNotFoundError{ID: ruleID}
+	}
+
+	var members []ecloudservice.AffinityRuleMember
+	for _, member := range fakeList(f, f.affinityRuleMembers, parameters) {
+		if member.AffinityRuleID == ruleID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (f *fakeECloudService) GetAffinityRuleMember(memberID string) (ecloudservice.AffinityRuleMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.affinityRuleMembers, memberID)
+	if !ok {
+		return ecloudservice.AffinityRuleMember{}, &ecloudservice.AffinityRuleMemberNotFoundError{ID: memberID}
+	}
+	r.value.Sync = fakeSyncStatus(f, memberID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) CreateAffinityRuleMember(req ecloudservice.CreateAffinityRuleMemberRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.affinityRules, req.AffinityRuleID); !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.AffinityRuleNotFoundError{ID: req.AffinityRuleID}
+	}
+
+	member := ecloudservice.AffinityRuleMember{
+		ID:             f.newID("arm"),
+		AffinityRuleID: req.AffinityRuleID,
+		InstanceID:     req.InstanceID,
+	}
+	f.affinityRuleMembers[member.ID] = &fakeRecord[ecloudservice.AffinityRuleMember]{value: member, pending: f.SyncSteps}
+	return ecloudservice.TaskReference{TaskID: f.newTask(member.ID, "affinity_rule_member_create"), ResourceID: member.ID}, nil
+}
+
+func (f *fakeECloudService) DeleteAffinityRuleMember(memberID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.affinityRuleMembers, memberID); !ok {
+		return "", &ecloudservice.AffinityRuleMemberNotFoundError{ID: memberID}
+	}
+	delete(f.affinityRuleMembers, memberID)
+	return f.newTask(memberID, "affinity_rule_member_delete"), nil
+}
+
+// Instances
+
+func (f *fakeECloudService) GetInstance(instanceID string) (ecloudservice.Instance, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.instances, instanceID)
+	if !ok {
+		return ecloudservice.Instance{}, &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	r.value.Sync = fakeSyncStatus(f, instanceID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetInstances(parameters connection.APIRequestParameters) ([]ecloudservice.Instance, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.instances, parameters), nil
+}
+
+func (f *fakeECloudService) GetInstancesPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.Instance], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakePaginated(fakeList(f, f.instances, parameters), parameters, f.GetInstancesPaginated), nil
+}
+
+func (f *fakeECloudService) CreateInstance(req ecloudservice.CreateInstanceRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	online := true
+	instance := ecloudservice.Instance{
+		ID:                  f.newID("i"),
+		Name:                req.Name,
+		VPCID:               req.VPCID,
+		ImageID:             req.ImageID,
+		VCPUCores:           req.VCPUCores,
+		VCPUSockets:         req.VCPUSockets,
+		VCPUCoresPerSocket:  req.VCPUCoresPerSocket,
+		RAMCapacity:         req.RAMCapacity,
+		Locked:              req.Locked,
+		BackupEnabled:       req.BackupEnabled,
+		BackupGatewayID:     req.BackupGatewayID,
+		MonitoringEnabled:   req.MonitoringEnabled,
+		MonitoringGatewayID: req.MonitoringGatewayID,
+		IsEncrypted:         req.IsEncrypted,
+		VolumeCapacity:      req.VolumeCapacity,
+		HostGroupID:         req.HostGroupID,
+		ResourceTierID:      req.ResourceTierID,
+		Online:              &online,
+	}
+	if instance.VCPUCores == 0 {
+		instance.VCPUCores = instance.VCPUSockets * instance.VCPUCoresPerSocket
+	} else if instance.VCPUSockets == 0 {
+		instance.VCPUSockets = instance.VCPUCores
+		instance.VCPUCoresPerSocket = 1
+	}
+	for _, tagID := range req.TagIDs {
+		instance.Tags = append(instance.Tags, ecloudservice.ResourceTag{ID: tagID})
+	}
+	f.instances[instance.ID] = &fakeRecord[ecloudservice.Instance]{value: instance, pending: f.SyncSteps}
+
+	iops := req.VolumeIOPS
+	if iops == 0 {
+		iops = 300
+	}
+	osVolume := ecloudservice.Volume{
+		ID:          f.newID("vol"),
+		Name:        instance.ID + "-os",
+		VPCID:       req.VPCID,
+		Capacity:    req.VolumeCapacity,
+		IOPS:        iops,
+		Attached:    true,
+		Type:        ecloudservice.VolumeTypeOS,
+		IsEncrypted: req.IsEncrypted,
+	}
+	f.volumes[osVolume.ID] = &fakeRecord[ecloudservice.Volume]{value: osVolume}
+	f.instanceVolumes[instance.ID] = []string{osVolume.ID}
+
+	nic := ecloudservice.NIC{
+		ID:         f.newID("nic"),
+		MACAddress: fmt.Sprintf("00:50:56:00:%02x:%02x", (f.seq>>8)&0xff, f.seq&0xff),
+		InstanceID: instance.ID,
+		NetworkID:  req.NetworkID,
+		IPAddress:  string(req.CustomIPAddress),
+	}
+	f.nics[nic.ID] = &fakeRecord[ecloudservice.NIC]{value: nic}
+
+	fipID := req.FloatingIPID
+	if req.RequiresFloatingIP {
+		fip := ecloudservice.FloatingIP{
+			ID:    f.newID("fip"),
+			VPCID: req.VPCID,
+		}
+		f.floatingIPs[fip.ID] = &fakeRecord[ecloudservice.FloatingIP]{value: fip}
+		fipID = fip.ID
+	}
+	if fip, ok := fakeGet(f, f.floatingIPs, fipID); ok {
+		fip.value.ResourceID = nic.ID
+	}
+
+	return instance.ID, nil
+}
+
+func (f *fakeECloudService) PatchInstance(instanceID string, req ecloudservice.PatchInstanceRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.instances, instanceID)
+	if !ok {
+		return &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	if req.VCPUCores != 0 {
+		r.value.VCPUCores = req.VCPUCores
+		r.value.VCPUSockets = req.VCPUCores
+		r.value.VCPUCoresPerSocket = 1
+	}
+	if req.VCPUSockets != 0 {
+		r.value.VCPUSockets = req.VCPUSockets
+	}
+	if req.VCPUCoresPerSocket != 0 {
+		r.value.VCPUCoresPerSocket = req.VCPUCoresPerSocket
+	}
+	if req.VCPUSockets != 0 || req.VCPUCoresPerSocket != 0 {
+		r.value.VCPUCores = r.value.VCPUSockets * r.value.VCPUCoresPerSocket
+	}
+	if req.RAMCapacity != 0 {
+		r.value.RAMCapacity = req.RAMCapacity
+	}
+	if req.VolumeGroupID != nil {
+		r.value.VolumeGroupID = *req.VolumeGroupID
+	}
+	if req.BackupGatewayID != "" {
+		r.value.BackupGatewayID = req.BackupGatewayID
+	}
+	if req.MonitoringEnabled != nil {
+		r.value.MonitoringEnabled = *req.MonitoringEnabled
+	}
+	if req.MonitoringGatewayID != nil {
+		r.value.MonitoringGatewayID = *req.MonitoringGatewayID
+	}
+	if req.TagIDs != nil {
+		r.value.Tags = nil
+		for _, tagID := range *req.TagIDs {
+			r.value.Tags = append(r.value.Tags, ecloudservice.ResourceTag{ID: tagID})
+		}
+	}
+	fakeTouch(f, r)
+	return nil
+}
+
+func (f *fakeECloudService) DeleteInstance(instanceID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	for _, volumeID := range f.instanceVolumes[instanceID] {
+		if volume, ok := fakeGet(f, f.volumes, volumeID); ok {
+			if volume.value.Type == ecloudservice.VolumeTypeOS {
+				delete(f.volumes, volumeID)
+				continue
+			}
+			volume.value.Attached = false
+		}
+	}
+	delete(f.instanceVolumes, instanceID)
+	for id, nic := range f.nics {
+		if nic.value.InstanceID == instanceID {
+			delete(f.nics, id)
+		}
+	}
+	fakeMarkDeleted(f, f.instances, instanceID)
+	return nil
+}
+
+func (f *fakeECloudService) MigrateInstance(instanceID string, req ecloudservice.MigrateInstanceRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.instances, instanceID)
+	if !ok {
+		return "", &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	r.value.HostGroupID = req.HostGroupID
+	r.value.ResourceTierID = req.ResourceTierID
+	return f.newTask(instanceID, "instance_migrate"), nil
+}
+
+func (f *fakeECloudService) EncryptInstance(instanceID string) (string, error) {
+	return f.setInstanceEncryption(instanceID, true)
+}
+
+func (f *fakeECloudService) DecryptInstance(instanceID string) (string, error) {
+	return f.setInstanceEncryption(instanceID, false)
+}
+
+func (f *fakeECloudService) setInstanceEncryption(instanceID string, encrypted bool) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.instances, instanceID)
+	if !ok {
+		return "", &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	r.value.IsEncrypted = encrypted
+	return f.newTask(instanceID, "instance_encryption"), nil
+}
+
+func (f *fakeECloudService) setInstanceOnline(instanceID string, online bool, taskName string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.instances, instanceID)
+	if !ok {
+		return "", &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	taskID := f.newTask(instanceID, taskName)
+	if taskName == "instance_power_shutdown" && f.FailShutdown {
+		f.failed[taskID] = true
+		return taskID, nil
+	}
+	if taskName == "instance_power_shutdown" && f.HangShutdown {
+		f.tasks[taskID].pending = math.MaxInt
+		return taskID, nil
+	}
+	if online && f.StayOffline {
+		return taskID, nil
+	}
+	r.value.Online = &online
+	return taskID, nil
+}
+
+func (f *fakeECloudService) PowerOnInstance(instanceID string) (string, error) {
+	return f.setInstanceOnline(instanceID, true, "instance_power_on")
+}
+
+func (f *fakeECloudService) PowerOffInstance(instanceID string) (string, error) {
+	return f.setInstanceOnline(instanceID, false, "instance_power_off")
+}
+
+func (f *fakeECloudService) PowerShutdownInstance(instanceID string) (string, error) {
+	return f.setInstanceOnline(instanceID, false, "instance_power_shutdown")
+}
+
+func (f *fakeECloudService) ExecuteInstanceScript(instanceID string, req ecloudservice.ExecuteInstanceScriptRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return "", &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	f.instanceScripts[instanceID] = append(f.instanceScripts[instanceID], req)
+	taskID := f.newTask(instanceID, "instance_user_script")
+	if f.FailScripts {
+		f.failed[taskID] = true
+	}
+	return taskID, nil
+}
+
+// Scripts returns the scripts executed on the instance with given ID, in order
+func (f *fakeECloudService) Scripts(instanceID string) []string {
+	var scripts []string
+	for _, req := range f.ScriptRequests(instanceID) {
+		scripts = append(scripts, req.Script)
+	}
+	return scripts
+}
+
+// ScriptRequests returns the requests to execute scripts on the instance with given ID, in order
+func (f *fakeECloudService) ScriptRequests(instanceID string) []ecloudservice.ExecuteInstanceScriptRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]ecloudservice.ExecuteInstanceScriptRequest(nil), f.instanceScripts[instanceID]...)
+}
+
+func (f *fakeECloudService) GetInstanceCredentials(instanceID string, parameters connection.APIRequestParameters) ([]ecloudservice.Credential, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return nil, &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+
+	var credentials []ecloudservice.Credential
+	for _, credential := range f.instanceCredentials[instanceID] {
+		if fakeMatchesFilters(credential, parameters.Filtering) {
+			credentials = append(credentials, credential)
+		}
+	}
+	return credentials, nil
+}
+
+// AddInstanceCredential adds a credential for the user with given username and password to the
+// instance with given ID
+func (f *fakeECloudService) AddInstanceCredential(instanceID string, username string, password string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	credential := ecloudservice.Credential{
+		ID:         f.newID("cred"),
+		Name:       username,
+		ResourceID: instanceID,
+		Username:   username,
+		Password:   password,
+	}
+	f.instanceCredentials[instanceID] = append(f.instanceCredentials[instanceID], credential)
+	return credential.ID
+}
+
+func (f *fakeECloudService) GetInstanceVolumes(instanceID string, parameters connection.APIRequestParameters) ([]ecloudservice.Volume, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return nil, &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+
+	var volumes []ecloudservice.Volume
+	for _, volumeID := range f.instanceVolumes[instanceID] {
+		if volume, ok := fakeGet(f, f.volumes, volumeID); ok && fakeMatchesFilters(volume.value, parameters.Filtering) {
+			volumes = append(volumes, volume.value)
+		}
+	}
+	return volumes, nil
+}
+
+func (f *fakeECloudService) GetInstanceNICs(instanceID string, parameters connection.APIRequestParameters) ([]ecloudservice.NIC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return nil, &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+
+	var nics []ecloudservice.NIC
+	for _, nic := range fakeList(f, f.nics, parameters) {
+		if nic.InstanceID == instanceID {
+			nics = append(nics, nic)
+		}
+	}
+	return nics, nil
+}
+
+func (f *fakeECloudService) GetInstanceFloatingIPs(instanceID string, parameters connection.APIRequestParameters) ([]ecloudservice.FloatingIP, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return nil, &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+
+	var fips []ecloudservice.FloatingIP
+	for _, fip := range fakeList(f, f.floatingIPs, parameters) {
+		if nic, ok := fakeGet(f, f.nics, fip.ResourceID); ok && nic.value.InstanceID == instanceID {
+			fips = append(fips, fip)
+		}
+	}
+	return fips, nil
+}
+
+func (f *fakeECloudService) AttachInstanceVolume(instanceID string, req ecloudservice.AttachDetachInstanceVolumeRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return "", &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	volume, ok := fakeGet(f, f.volumes, req.VolumeID)
+	if !ok {
+		return "", &ecloudservice.VolumeNotFoundError{ID: req.VolumeID}
+	}
+	volume.value.Attached = true
+	f.instanceVolumes[instanceID] = append(f.instanceVolumes[instanceID], req.VolumeID)
+	return f.newTask(instanceID, "volume_attach"), nil
+}
+
+func (f *fakeECloudService) DetachInstanceVolume(instanceID string, req ecloudservice.AttachDetachInstanceVolumeRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, instanceID); !ok {
+		return "", &ecloudservice.InstanceNotFoundError{ID: instanceID}
+	}
+	var remaining []string
+	for _, volumeID := range f.instanceVolumes[instanceID] {
+		if volumeID != req.VolumeID {
+			remaining = append(remaining, volumeID)
+		}
+	}
+	f.instanceVolumes[instanceID] = remaining
+	if volume, ok := fakeGet(f, f.volumes, req.VolumeID); ok {
+		volume.value.Attached = false
+	}
+	return f.newTask(instanceID, "volume_detach"), nil
+}
+
+// Volumes
+
+func (f *fakeECloudService) GetVolume(volumeID string) (ecloudservice.Volume, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.volumes, volumeID)
+	if !ok {
+		return ecloudservice.Volume{}, &ecloudservice.VolumeNotFoundError{ID: volumeID}
+	}
+	r.value.Sync = fakeSyncStatus(f, volumeID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetVolumes(parameters connection.APIRequestParameters) ([]ecloudservice.Volume, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.volumes, parameters), nil
+}
+
+func (f *fakeECloudService) GetVolumesPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.Volume], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakePaginated(fakeList(f, f.volumes, parameters), parameters, f.GetVolumesPaginated), nil
+}
+
+func (f *fakeECloudService) CreateVolume(req ecloudservice.CreateVolumeRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	volume := ecloudservice.Volume{
+		ID:                 f.newID("vol"),
+		Name:               req.Name,
+		VPCID:              req.VPCID,
+		AvailabilityZoneID: req.AvailabilityZoneID,
+		Capacity:           req.Capacity,
+		IOPS:               req.IOPS,
+		Type:               ecloudservice.VolumeTypeData,
+		VolumeGroupID:      req.VolumeGroupID,
+		IsShared:           req.IsShared,
+	}
+	if volume.IOPS == 0 {
+		volume.IOPS = 300
+	}
+	f.volumes[volume.ID] = &fakeRecord[ecloudservice.Volume]{value: volume, pending: f.SyncSteps}
+	return ecloudservice.TaskReference{TaskID: f.newTask(volume.ID, "volume_create"), ResourceID: volume.ID}, nil
+}
+
+func (f *fakeECloudService) PatchVolume(volumeID string, req ecloudservice.PatchVolumeRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.volumes, volumeID)
+	if !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.VolumeNotFoundError{ID: volumeID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	if req.Capacity != 0 {
+		r.value.Capacity = req.Capacity
+	}
+	if req.IOPS != 0 {
+		r.value.IOPS = req.IOPS
+	}
+	if req.VolumeGroupID != nil {
+		r.value.VolumeGroupID = *req.VolumeGroupID
+	}
+	fakeTouch(f, r)
+	return ecloudservice.TaskReference{TaskID: f.newTask(volumeID, "volume_update"), ResourceID: volumeID}, nil
+}
+
+func (f *fakeECloudService) DeleteVolume(volumeID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.volumes, volumeID); !ok {
+		return "", &ecloudservice.VolumeNotFoundError{ID: volumeID}
+	}
+	delete(f.volumes, volumeID)
+	return f.newTask(volumeID, "volume_delete"), nil
+}
+
+// Floating IPs
+
+func (f *fakeECloudService) GetFloatingIP(fipID string) (ecloudservice.FloatingIP, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.floatingIPs, fipID)
+	if !ok {
+		return ecloudservice.FloatingIP{}, &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+	}
+	r.value.Sync = fakeSyncStatus(f, fipID, r)
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetFloatingIPs(parameters connection.APIRequestParameters) ([]ecloudservice.FloatingIP, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.floatingIPs, parameters), nil
+}
+
+func (f *fakeECloudService) GetFloatingIPsPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.FloatingIP], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakePaginated(fakeList(f, f.floatingIPs, parameters), parameters, f.GetFloatingIPsPaginated), nil
+}
+
+func (f *fakeECloudService) CreateFloatingIP(req ecloudservice.CreateFloatingIPRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fip := ecloudservice.FloatingIP{
+		ID:                 f.newID("fip"),
+		Name:               req.Name,
+		VPCID:              req.VPCID,
+		AvailabilityZoneID: req.AvailabilityZoneID,
+		IPAddress:          fmt.Sprintf("203.0.113.%d", f.seq%254+1),
+	}
+	f.floatingIPs[fip.ID] = &fakeRecord[ecloudservice.FloatingIP]{value: fip, pending: f.SyncSteps}
+	return ecloudservice.TaskReference{TaskID: f.newTask(fip.ID, "floating_ip_create"), ResourceID: fip.ID}, nil
+}
+
+func (f *fakeECloudService) PatchFloatingIP(fipID string, req ecloudservice.PatchFloatingIPRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.floatingIPs, fipID)
+	if !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+	}
+	if req.Name != "" {
+		r.value.Name = req.Name
+	}
+	fakeTouch(f, r)
+	return ecloudservice.TaskReference{TaskID: f.newTask(fipID, "floating_ip_update"), ResourceID: fipID}, nil
+}
+
+func (f *fakeECloudService) DeleteFloatingIP(fipID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.floatingIPs, fipID); !ok {
+		return "", &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+	}
+	delete(f.floatingIPs, fipID)
+	return f.newTask(fipID, "floating_ip_delete"), nil
+}
+
+func (f *fakeECloudService) AssignFloatingIP(fipID string, req ecloudservice.AssignFloatingIPRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.floatingIPs, fipID)
+	if !ok {
+		return "", &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+	}
+	r.value.ResourceID = req.ResourceID
+	return f.newTask(fipID, "floating_ip_assign"), nil
+}
+
+func (f *fakeECloudService) UnassignFloatingIP(fipID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.floatingIPs, fipID)
+	if !ok {
+		return "", &ecloudservice.FloatingIPNotFoundError{ID: fipID}
+	}
+	r.value.ResourceID = ""
+	return f.newTask(fipID, "floating_ip_unassign"), nil
+}
+
+// NICs
+
+func (f *fakeECloudService) GetNIC(nicID string) (ecloudservice.NIC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.nics, nicID)
+	if !ok {
+		return ecloudservice.NIC{}, &ecloudservice.NICNotFoundError{ID: nicID}
+	}
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetNICs(parameters connection.APIRequestParameters) ([]ecloudservice.NIC, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.nics, parameters), nil
+}
+
+func (f *fakeECloudService) CreateNIC(req ecloudservice.CreateNICRequest) (ecloudservice.TaskReference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.instances, req.InstanceID); !ok {
+		return ecloudservice.TaskReference{}, &ecloudservice.InstanceNotFoundError{ID: req.InstanceID}
+	}
+
+	nic := ecloudservice.NIC{
+		ID:         f.newID("nic"),
+		Name:       req.Name,
+		MACAddress: fmt.Sprintf("00:50:56:00:%02x:%02x", (f.seq>>8)&0xff, f.seq&0xff),
+		InstanceID: req.InstanceID,
+		NetworkID:  req.NetworkID,
+	}
+	f.nics[nic.ID] = &fakeRecord[ecloudservice.NIC]{value: nic}
+	return ecloudservice.TaskReference{TaskID: f.newTask(nic.ID, "nic_create"), ResourceID: nic.ID}, nil
+}
+
+func (f *fakeECloudService) DeleteNIC(nicID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := fakeGet(f, f.nics, nicID); !ok {
+		return "", &ecloudservice.NICNotFoundError{ID: nicID}
+	}
+	delete(f.nics, nicID)
+	return f.newTask(nicID, "nic_delete"), nil
+}
+
+// Host groups, host specs, resource tiers and IOPS tiers
+
+// AddHostGroup adds a host group with a host spec of given CPU and RAM capacity, returning the
+// ID of the host group
+func (f *fakeECloudService) AddHostGroup(spec ecloudservice.HostSpec) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	spec.ID = f.newID("hs")
+	f.hostSpecs[spec.ID] = &fakeRecord[ecloudservice.HostSpec]{value: spec}
+
+	hostGroup := ecloudservice.HostGroup{
+		ID:         f.newID("hg"),
+		HostSpecID: spec.ID,
+	}
+	f.hostGroups[hostGroup.ID] = &fakeRecord[ecloudservice.HostGroup]{value: hostGroup}
+	return hostGroup.ID
+}
+
+// AddResourceTier adds a resource tier in the availability zone with given ID, returning its ID
+func (f *fakeECloudService) AddResourceTier(azID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tier := ecloudservice.ResourceTier{
+		ID:                 f.newID("rt"),
+		AvailabilityZoneID: azID,
+	}
+	f.resourceTiers[tier.ID] = &fakeRecord[ecloudservice.ResourceTier]{value: tier}
+	return tier.ID
+}
+
+// AddIOPSTiers adds IOPS tiers of given levels to the availability zone with given ID
+func (f *fakeECloudService) AddIOPSTiers(azID string, levels ...int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, level := range levels {
+		f.iopsTiers[azID] = append(f.iopsTiers[azID], ecloudservice.IOPSTier{ID: f.newID("iops"), Level: level})
+	}
+}
+
+func (f *fakeECloudService) GetHostGroup(hostGroupID string) (ecloudservice.HostGroup, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.hostGroups, hostGroupID)
+	if !ok {
+		return ecloudservice.HostGroup{}, &ecloudservice.HostGroupNotFoundError{ID: hostGroupID}
+	}
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetHostSpec(specID string) (ecloudservice.HostSpec, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.hostSpecs, specID)
+	if !ok {
+		return ecloudservice.HostSpec{}, &ecloudservice.HostSpecNotFoundError{ID: specID}
+	}
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetResourceTier(tierID string) (ecloudservice.ResourceTier, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := fakeGet(f, f.resourceTiers, tierID)
+	if !ok {
+		return ecloudservice.ResourceTier{}, &ecloudservice.ResourceTierNotFoundError{ID: tierID}
+	}
+	return r.value, nil
+}
+
+func (f *fakeECloudService) GetAvailabilityZoneIOPSTiers(azID string, parameters connection.APIRequestParameters) ([]ecloudservice.IOPSTier, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.iopsTiers[azID], nil
+}
+
+func (f *fakeECloudService) GetIOPSTiers(parameters connection.APIRequestParameters) ([]ecloudservice.IOPSTier, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	azIDs := make([]string, 0, len(f.iopsTiers))
+	for azID := range f.iopsTiers {
+		azIDs = append(azIDs, azID)
+	}
+	sort.Strings(azIDs)
+
+	var tiers []ecloudservice.IOPSTier
+	for _, azID := range azIDs {
+		tiers = append(tiers, f.iopsTiers[azID]...)
+	}
+	return tiers, nil
+}
+
+// Images
+
+// AddImage adds an image, returning its ID
+func (f *fakeECloudService) AddImage(image ecloudservice.Image) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	image.ID = f.newID("img")
+	f.images[image.ID] = &fakeRecord[ecloudservice.Image]{value: image}
+	return image.ID
+}
+
+func (f *fakeECloudService) GetImages(parameters connection.APIRequestParameters) ([]ecloudservice.Image, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakeList(f, f.images, parameters), nil
+}
+
+func (f *fakeECloudService) GetImagesPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[ecloudservice.Image], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fakePaginated(fakeList(f, f.images, parameters), parameters, f.GetImagesPaginated), nil
+}
@@ -45,3 +45,18 @@ func flattenInstanceTags(tags []ecloudservice.ResourceTag) []interface{} {
 
 	return flattenedTags
 }
+
+func flattenInstanceNICs(nics []ecloudservice.NIC) []interface{} {
+	flattenedNICs := make([]interface{}, len(nics))
+
+	for i, nic := range nics {
+		flattenedNIC := make(map[string]interface{})
+		flattenedNIC["id"] = nic.ID
+		flattenedNIC["network_id"] = nic.NetworkID
+		flattenedNIC["ip_address"] = nic.IPAddress
+		flattenedNIC["mac_address"] = nic.MACAddress
+		flattenedNICs[i] = flattenedNIC
+	}
+
+	return flattenedNICs
+}
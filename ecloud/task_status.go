@@ -9,7 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
-// TaskStatusRefreshFunc returns a function with StateRefreshFunc signature for use with StateChangeConf
+// TaskStatusRefreshFunc returns a function with StateRefreshFunc signature for use with waitForResourceState
 func TaskStatusRefreshFunc(ctx context.Context, service ecloudservice.ECloudService, taskID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		tflog.Debug(ctx, "Retrieving task status", map[string]interface{}{
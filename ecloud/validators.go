@@ -0,0 +1,42 @@
+package ecloud
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// validateEnum returns a validator accepting the values of enum. Case is ignored, as with enum.Parse
+func validateEnum[T connection.EnumValue](enum connection.Enum[T]) schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringInSlice(enum.Values(), true))
+}
+
+// validateCIDR validates a CIDR, e.g. 10.0.0.0/24
+var validateCIDR = validation.ToDiagFunc(validation.IsCIDR)
+
+// validateIPAddress validates an IPv4 or IPv6 address
+var validateIPAddress = validation.ToDiagFunc(validation.IsIPAddress)
+
+// validateCIDRList validates a comma separated list of CIDRs, e.g. 10.0.0.0/24,10.0.1.0/24
+var validateCIDRList = validation.ToDiagFunc(func(val interface{}, key string) (warns []string, errs []error) {
+	v, ok := val.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected type of %q to be string", key))
+		return
+	}
+
+	if v == "" {
+		return
+	}
+
+	for _, cidr := range strings.Split(v, ",") {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			errs = append(errs, fmt.Errorf("%q must be a comma separated list of valid CIDRs, got invalid CIDR: %s", key, cidr))
+		}
+	}
+	return
+})
@@ -0,0 +1,53 @@
+package ecloud
+
+import (
+	"testing"
+
+	ecloudservice "github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEnum(t *testing.T) {
+	validate := validateEnum(ecloudservice.FirewallRuleDirectionEnum)
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.False(t, validate("IN_OUT", cty.Path{}).HasError())
+	})
+
+	t.Run("DifferentCase_Valid", func(t *testing.T) {
+		assert.False(t, validate("in", cty.Path{}).HasError())
+	})
+
+	t.Run("Invalid_ListsValues", func(t *testing.T) {
+		diags := validate("INBOUND", cty.Path{})
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, `["IN" "OUT" "IN_OUT"]`)
+	})
+}
+
+func TestValidateCIDR(t *testing.T) {
+	assert.False(t, validateCIDR("10.0.0.0/24", cty.Path{}).HasError())
+	assert.True(t, validateCIDR("10.0.0.0", cty.Path{}).HasError())
+}
+
+func TestValidateIPAddress(t *testing.T) {
+	assert.False(t, validateIPAddress("10.0.0.5", cty.Path{}).HasError())
+	assert.True(t, validateIPAddress("10.0.0.256", cty.Path{}).HasError())
+}
+
+func TestValidateCIDRList(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.False(t, validateCIDRList("10.0.0.0/24,10.0.1.0/24", cty.Path{}).HasError())
+	})
+
+	t.Run("Empty_Valid", func(t *testing.T) {
+		assert.False(t, validateCIDRList("", cty.Path{}).HasError())
+	})
+
+	t.Run("Invalid_ReturnsError", func(t *testing.T) {
+		diags := validateCIDRList("10.0.0.0/24,10.0.1.0", cty.Path{})
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "got invalid CIDR: 10.0.1.0")
+	})
+}
@@ -0,0 +1,47 @@
+package kvmutex
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+var fileLockNameRegexp = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// FileLockerFactory returns lockers backed by advisory file locks within a directory, so that
+// keys are serialised across processes on the same host as well as within this one
+type FileLockerFactory struct {
+	dir string
+}
+
+// NewFileLockerFactory returns a FileLockerFactory using dir, creating it if required
+func NewFileLockerFactory(dir string) (*FileLockerFactory, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory [%s]: %w", dir, err)
+	}
+
+	// verify lock files can be created up front, as sync.Locker has no way to report errors
+	f, err := os.CreateTemp(dir, ".lockcheck")
+	if err != nil {
+		return nil, fmt.Errorf("lock directory [%s] is not writable: %w", dir, err)
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	return &FileLockerFactory{dir: dir}, nil
+}
+
+func (f *FileLockerFactory) Get(key string) sync.Locker {
+	return &FileLocker{
+		path: filepath.Join(f.dir, fileLockNameRegexp.ReplaceAllString(key, "_")+".lock"),
+	}
+}
+
+// FileLocker holds an in-process mutex alongside an advisory lock on path
+type FileLocker struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
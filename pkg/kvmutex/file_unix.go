@@ -0,0 +1,55 @@
+//go:build !windows
+
+package kvmutex
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"syscall"
+)
+
+// Lock locks l, falling back to only locking within this process where the file lock fails.
+// LockOrError should be used where the failure can be reported
+func (l *FileLocker) Lock() {
+	if err := l.LockOrError(); err != nil {
+		log.Printf("[ERROR] %s, lock will only apply to this process", err)
+		l.mu.Lock()
+	}
+}
+
+// LockOrError locks l, returning an error where the lock file can't be opened or locked
+func (l *FileLocker) LockOrError() error {
+	l.mu.Lock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		l.mu.Unlock()
+		return fmt.Errorf("failed to open lock file [%s]: %w", l.path, err)
+	}
+
+	for {
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			break
+		}
+	}
+	if err != nil {
+		f.Close()
+		l.mu.Unlock()
+		return fmt.Errorf("failed to lock file [%s]: %w", l.path, err)
+	}
+
+	l.file = f
+	return nil
+}
+
+func (l *FileLocker) Unlock() {
+	if l.file != nil {
+		syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+		l.file.Close()
+		l.file = nil
+	}
+
+	l.mu.Unlock()
+}
@@ -0,0 +1,87 @@
+//go:build !windows
+
+package kvmutex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLockerFactory_Get_SanitisesKey(t *testing.T) {
+	dir := t.TempDir()
+	factory, err := NewFileLockerFactory(dir)
+	assert.Nil(t, err)
+
+	locker := factory.Get("../fwp-abcdef12").(*FileLocker)
+
+	assert.Equal(t, filepath.Join(dir, ".._fwp-abcdef12.lock"), locker.path)
+}
+
+func TestFileLocker_Lock_ExcludesOtherFactories(t *testing.T) {
+	dir := t.TempDir()
+
+	// separate factories open separate file descriptions, as separate processes would
+	first, err := NewFileLockerFactory(dir)
+	assert.Nil(t, err)
+	second, err := NewFileLockerFactory(dir)
+	assert.Nil(t, err)
+
+	firstLocker := first.Get("fwp-abcdef12")
+	secondLocker := second.Get("fwp-abcdef12")
+
+	firstLocker.Lock()
+
+	acquired := make(chan struct{})
+	go func() {
+		secondLocker.Lock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("expected second locker to block whilst first holds lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	firstLocker.Unlock()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("expected second locker to acquire lock once released")
+	}
+
+	secondLocker.Unlock()
+}
+
+func TestNewFileLockerFactory_CreatesDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "locks")
+
+	_, err := NewFileLockerFactory(dir)
+
+	assert.Nil(t, err)
+	assert.DirExists(t, dir)
+}
+
+func TestKVMutex_LockContext_ReturnsFileLockError(t *testing.T) {
+	dir := t.TempDir()
+	factory, err := NewFileLockerFactory(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, os.RemoveAll(dir))
+
+	mutex := NewKVMutex().WithFactory(factory)
+
+	_, err = mutex.LockContext(context.Background(), "fwp-abcdef12")
+	assert.ErrorContains(t, err, "failed to acquire lock on [fwp-abcdef12]: failed to open lock file")
+
+	// the in-process lock must have been released
+	assert.Nil(t, os.MkdirAll(dir, 0700))
+	unlock, err := mutex.LockContext(context.Background(), "fwp-abcdef12")
+	assert.Nil(t, err)
+	unlock()
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package kvmutex
+
+import (
+	"log"
+)
+
+// File locks aren't supported on Windows, so FileLocker only serialises within this process
+
+func (l *FileLocker) Lock() {
+	l.LockOrError()
+}
+
+func (l *FileLocker) LockOrError() error {
+	l.mu.Lock()
+	log.Printf("[WARN] File locks are not supported on Windows, lock [%s] will only apply to this process", l.path)
+	return nil
+}
+
+func (l *FileLocker) Unlock() {
+	l.mu.Unlock()
+}
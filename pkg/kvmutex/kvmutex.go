@@ -1,22 +1,55 @@
 package kvmutex
 
 import (
+	"context"
+	"fmt"
 	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type LockerFactory interface {
-	Get() sync.Locker
+	Get(key string) sync.Locker
+}
+
+// FallibleLocker is a sync.Locker whose lock can fail, such as a FileLocker. LockContext returns
+// the error from LockOrError, rather than falling back to a lock which may not give the
+// exclusion expected
+type FallibleLocker interface {
+	sync.Locker
+	LockOrError() error
 }
 
 type KVMutexLockerFactory struct{}
 
-func (f *KVMutexLockerFactory) Get() sync.Locker {
+func (f *KVMutexLockerFactory) Get(key string) sync.Locker {
 	return &sync.Mutex{}
 }
 
+type holderContextKey struct{}
+
+// WithHolder returns a copy of ctx identifying the holder of any lock taken with it
+func WithHolder(ctx context.Context, holder string) context.Context {
+	return context.WithValue(ctx, holderContextKey{}, holder)
+}
+
+func holderFromContext(ctx context.Context) string {
+	if holder, ok := ctx.Value(holderContextKey{}).(string); ok {
+		return holder
+	}
+	return "unknown"
+}
+
+type lockHolder struct {
+	name  string
+	since time.Time
+}
+
 type KVMutex struct {
 	mutexesLock sync.Mutex
 	mutexes     map[string]sync.Locker
+	holders     map[string]lockHolder
 	factory     LockerFactory
 }
 
@@ -24,6 +57,7 @@ type KVMutex struct {
 func NewKVMutex() *KVMutex {
 	return &KVMutex{
 		mutexes: make(map[string]sync.Locker),
+		holders: make(map[string]lockHolder),
 		factory: &KVMutexLockerFactory{},
 	}
 }
@@ -41,7 +75,69 @@ func (m *KVMutex) Lock(key string) func() {
 	}
 }
 
+// LockContext locks key, returning an error if ctx is cancelled or its deadline passes
+// before the lock is acquired. The holder is taken from ctx (see WithHolder)
+func (m *KVMutex) LockContext(ctx context.Context, key string) (func(), error) {
+	mutex := m.get(key)
+	holder := holderFromContext(ctx)
+	start := time.Now()
+
+	if current, ok := m.holder(key); ok {
+		tflog.Debug(ctx, "Waiting for lock", map[string]interface{}{
+			"key":      key,
+			"holder":   holder,
+			"held_by":  current.name,
+			"held_for": time.Since(current.since).String(),
+		})
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		if fallible, ok := mutex.(FallibleLocker); ok {
+			acquired <- fallible.LockOrError()
+			return
+		}
+
+		mutex.Lock()
+		acquired <- nil
+	}()
+
+	select {
+	case err := <-acquired:
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire lock on [%s]: %w", key, err)
+		}
+	case <-ctx.Done():
+		// The underlying locker can't be abandoned, so release it as soon as it's acquired
+		go func() {
+			if err := <-acquired; err == nil {
+				mutex.Unlock()
+			}
+		}()
+
+		heldBy := "unknown"
+		if current, ok := m.holder(key); ok {
+			heldBy = current.name
+		}
+		return nil, fmt.Errorf("failed to acquire lock on [%s] after %s, held by [%s]: %w", key, time.Since(start).Round(time.Millisecond), heldBy, ctx.Err())
+	}
+
+	m.setHolder(key, holder)
+
+	tflog.Debug(ctx, "Acquired lock", map[string]interface{}{
+		"key":    key,
+		"holder": holder,
+		"waited": time.Since(start).String(),
+	})
+
+	return func() {
+		m.clearHolder(key)
+		mutex.Unlock()
+	}, nil
+}
+
 func (m *KVMutex) Unlock(key string) {
+	m.clearHolder(key)
 	m.get(key).Unlock()
 }
 
@@ -50,7 +146,26 @@ func (m *KVMutex) get(key string) sync.Locker {
 	defer m.mutexesLock.Unlock()
 	_, ok := m.mutexes[key]
 	if !ok {
-		m.mutexes[key] = m.factory.Get()
+		m.mutexes[key] = m.factory.Get(key)
 	}
 	return m.mutexes[key]
 }
+
+func (m *KVMutex) holder(key string) (lockHolder, bool) {
+	m.mutexesLock.Lock()
+	defer m.mutexesLock.Unlock()
+	h, ok := m.holders[key]
+	return h, ok
+}
+
+func (m *KVMutex) setHolder(key string, name string) {
+	m.mutexesLock.Lock()
+	defer m.mutexesLock.Unlock()
+	m.holders[key] = lockHolder{name: name, since: time.Now()}
+}
+
+func (m *KVMutex) clearHolder(key string) {
+	m.mutexesLock.Lock()
+	defer m.mutexesLock.Unlock()
+	delete(m.holders, key)
+}
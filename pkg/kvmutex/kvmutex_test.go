@@ -1,8 +1,10 @@
 package kvmutex
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -23,7 +25,7 @@ type TestLockerFactory struct {
 	locker sync.Locker
 }
 
-func (f *TestLockerFactory) Get() sync.Locker {
+func (f *TestLockerFactory) Get(key string) sync.Locker {
 	return f.locker
 }
 
@@ -46,3 +48,56 @@ func TestKVMutex_Unlock_Unlocks(t *testing.T) {
 	mutex.Unlock("somekey")
 	assert.False(t, lock.Locked)
 }
+
+func TestKVMutex_LockContext_Locks(t *testing.T) {
+	lock := &TestLocker{}
+	mutex := NewKVMutex().WithFactory(&TestLockerFactory{locker: lock})
+
+	unlock, err := mutex.LockContext(context.Background(), "somekey")
+
+	assert.Nil(t, err)
+	assert.True(t, lock.Locked)
+
+	unlock()
+	assert.False(t, lock.Locked)
+}
+
+func TestKVMutex_LockContext_TimesOutWhenHeld(t *testing.T) {
+	mutex := NewKVMutex()
+
+	unlock, err := mutex.LockContext(WithHolder(context.Background(), "firstholder"), "somekey")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = mutex.LockContext(ctx, "somekey")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "held by [firstholder]")
+
+	unlock()
+
+	// the abandoned attempt must not leave the lock held
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	unlock, err = mutex.LockContext(ctx, "somekey")
+	assert.Nil(t, err)
+	unlock()
+}
+
+func TestKVMutex_LockContext_DoesNotBlockOtherKeys(t *testing.T) {
+	mutex := NewKVMutex()
+
+	unlock, err := mutex.LockContext(context.Background(), "somekey")
+	assert.Nil(t, err)
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	unlockOther, err := mutex.LockContext(ctx, "otherkey")
+	assert.Nil(t, err)
+	unlockOther()
+}
@@ -0,0 +1,146 @@
+package limiter
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+)
+
+// Class identifies the queue a request waits in for a slot
+type Class int
+
+const (
+	// ClassRead is used for requests which don't modify resources
+	ClassRead Class = iota
+	// ClassMutate is used for requests which create, modify or delete resources
+	ClassMutate
+)
+
+// Limiter is a counting semaphore with a separate FIFO queue per request class. Freed slots
+// are handed to waiting classes in turn, so that a flood of polling reads cannot starve
+// mutating requests (and vice versa)
+type Limiter struct {
+	mu       sync.Mutex
+	capacity int
+	inUse    int
+	queues   [2][]chan struct{}
+	next     Class
+}
+
+// NewLimiter returns a Limiter allowing up to capacity concurrent holders
+func NewLimiter(capacity int) *Limiter {
+	return &Limiter{
+		capacity: capacity,
+	}
+}
+
+// Acquire blocks until a slot is available for given class
+func (l *Limiter) Acquire(class Class) {
+	l.mu.Lock()
+	if l.inUse < l.capacity && len(l.queues[ClassRead]) == 0 && len(l.queues[ClassMutate]) == 0 {
+		l.inUse++
+		l.mu.Unlock()
+		return
+	}
+
+	ready := make(chan struct{})
+	l.queues[class] = append(l.queues[class], ready)
+	l.mu.Unlock()
+
+	<-ready
+}
+
+// Release frees a slot, handing it directly to the next waiter if there is one
+func (l *Limiter) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := 0; i < len(l.queues); i++ {
+		class := (l.next + Class(i)) % Class(len(l.queues))
+		if len(l.queues[class]) > 0 {
+			ready := l.queues[class][0]
+			l.queues[class] = l.queues[class][1:]
+			l.next = (class + 1) % Class(len(l.queues))
+			close(ready)
+			return
+		}
+	}
+
+	l.inUse--
+}
+
+// Connection wraps a connection.Connection, holding a Limiter slot for the duration of
+// each request. Slots are only held whilst a request is in flight, so callers polling
+// for state changes don't consume capacity between polls
+type Connection struct {
+	conn    connection.Connection
+	limiter *Limiter
+}
+
+// NewConnection returns a Connection wrapping conn, limited by limiter
+func NewConnection(conn connection.Connection, limiter *Limiter) *Connection {
+	return &Connection{
+		conn:    conn,
+		limiter: limiter,
+	}
+}
+
+// Get invokes a GET request, returning an APIResponse
+func (c *Connection) Get(resource string, parameters connection.APIRequestParameters) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:     http.MethodGet,
+		Resource:   resource,
+		Parameters: parameters,
+	})
+}
+
+// Post invokes a POST request, returning an APIResponse
+func (c *Connection) Post(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodPost,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Put invokes a PUT request, returning an APIResponse
+func (c *Connection) Put(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodPut,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Patch invokes a PATCH request, returning an APIResponse
+func (c *Connection) Patch(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodPatch,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Delete invokes a DELETE request, returning an APIResponse
+func (c *Connection) Delete(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodDelete,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Invoke invokes a request once a slot is available, returning an APIResponse
+func (c *Connection) Invoke(request connection.APIRequest) (*connection.APIResponse, error) {
+	class := ClassMutate
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		class = ClassRead
+	}
+
+	c.limiter.Acquire(class)
+	defer c.limiter.Release()
+
+	return c.conn.Invoke(request)
+}
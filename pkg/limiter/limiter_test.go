@@ -0,0 +1,94 @@
+package limiter
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestConnection struct {
+	connection.Connection
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (c *TestConnection) Invoke(request connection.APIRequest) (*connection.APIResponse, error) {
+	n := atomic.AddInt32(&c.inFlight, 1)
+	for {
+		max := atomic.LoadInt32(&c.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&c.maxInFlight, max, n) {
+			break
+		}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	atomic.AddInt32(&c.inFlight, -1)
+
+	return &connection.APIResponse{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func TestConnection_Invoke_LimitsConcurrentRequests(t *testing.T) {
+	inner := &TestConnection{}
+	conn := NewConnection(inner, NewLimiter(3))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				conn.Get("/ecloud/v2/instances", connection.APIRequestParameters{})
+			} else {
+				conn.Post("/ecloud/v2/instances", nil)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, inner.maxInFlight, int32(3))
+	assert.Equal(t, int32(0), inner.inFlight)
+}
+
+func TestLimiter_Release_AlternatesBetweenClasses(t *testing.T) {
+	l := NewLimiter(1)
+	l.Acquire(ClassRead)
+
+	var mu sync.Mutex
+	var order []Class
+	var wg sync.WaitGroup
+
+	queue := func(class Class) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Acquire(class)
+			mu.Lock()
+			order = append(order, class)
+			mu.Unlock()
+			l.Release()
+		}()
+	}
+
+	// queue several reads ahead of a single mutating request
+	for i := 0; i < 3; i++ {
+		queue(ClassRead)
+	}
+	queue(ClassMutate)
+
+	assert.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return len(l.queues[ClassRead]) == 3 && len(l.queues[ClassMutate]) == 1
+	}, time.Second, time.Millisecond)
+
+	l.Release()
+	wg.Wait()
+
+	assert.Len(t, order, 4)
+	assert.Contains(t, order[:2], ClassMutate)
+}
@@ -1,14 +1,121 @@
 package lock
 
 import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/ukfast/terraform-provider-ecloud/pkg/kvmutex"
 )
 
-var kvMutex *kvmutex.KVMutex = kvmutex.NewKVMutex()
+var (
+	// kvMutexLock guards kvMutex, as the provider may be configured while locks are being
+	// taken from other goroutines
+	kvMutexLock sync.Mutex
+	kvMutex     = kvmutex.NewKVMutex()
+	// kvMutexUsed is set once a lock has been taken, after which kvMutex can't be replaced
+	kvMutexUsed bool
+	fileLockDir string
+)
+
+// UseFileLocks backs resource locks with advisory file locks in dir, serialising operations
+// across provider processes on the same host. It must be called before any locks are taken, as
+// locks held through the previous KVMutex wouldn't exclude those taken through the new one, so
+// returns an error where they have been. Calling it again with the same dir has no effect
+func UseFileLocks(dir string) error {
+	kvMutexLock.Lock()
+	defer kvMutexLock.Unlock()
+
+	if fileLockDir == dir {
+		return nil
+	}
+
+	factory, err := kvmutex.NewFileLockerFactory(dir)
+	if err != nil {
+		return err
+	}
+
+	if kvMutexUsed {
+		return fmt.Errorf("file locks must be configured before any resources are locked")
+	}
 
-func LockResource(resourceID string) func() {
-	return kvMutex.Lock(resourceID)
+	kvMutex = kvmutex.NewKVMutex().WithFactory(factory)
+	fileLockDir = dir
+	return nil
 }
+
+// currentKVMutex returns the KVMutex backing resource locks, preventing it from being replaced
+func currentKVMutex() *kvmutex.KVMutex {
+	kvMutexLock.Lock()
+	defer kvMutexLock.Unlock()
+
+	kvMutexUsed = true
+	return kvMutex
+}
+
+// LockResource locks resourceID, giving up when ctx is cancelled or times out. The calling
+// function and resourceID are recorded as the lock holder
+func LockResource(ctx context.Context, resourceID string) (func(), error) {
+	return currentKVMutex().LockContext(kvmutex.WithHolder(ctx, holder(caller(), resourceID)), resourceID)
+}
+
+// LockResources locks each of the given resource IDs in a canonical (sorted) order, so that
+// concurrent callers locking overlapping sets can't deadlock. Empty and duplicate IDs are
+// ignored. The returned func releases all locks
+func LockResources(ctx context.Context, resourceIDs ...string) (func(), error) {
+	name := caller()
+	m := currentKVMutex()
+
+	keys := make([]string, 0, len(resourceIDs))
+	seen := make(map[string]bool)
+	for _, id := range resourceIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		keys = append(keys, id)
+	}
+	sort.Strings(keys)
+	ctx = kvmutex.WithHolder(ctx, holder(name, keys...))
+
+	var unlocks []func()
+	unlockAll := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+
+	for _, key := range keys {
+		unlock, err := m.LockContext(ctx, key)
+		if err != nil {
+			unlockAll()
+			return nil, err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+
+	return unlockAll, nil
+}
+
 func UnlockResource(resourceID string) {
-	kvMutex.Unlock(resourceID)
+	currentKVMutex().Unlock(resourceID)
+}
+
+// holder returns the lock holder for function name locking keys
+func holder(name string, keys ...string) string {
+	return fmt.Sprintf("%s [%s]", name, strings.Join(keys, ", "))
+}
+
+// caller returns the unqualified name of the function calling into this package
+func caller() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+
+	name := runtime.FuncForPC(pc).Name()
+	return name[strings.LastIndex(name, ".")+1:]
 }
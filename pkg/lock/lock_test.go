@@ -0,0 +1,155 @@
+package lock
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/terraform-provider-ecloud/pkg/kvmutex"
+)
+
+func TestLockResources_OppositeOrders_DoesNotDeadlock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			unlock, err := LockResources(ctx, "fip-aaaaaaaa", "fip-bbbbbbbb")
+			if assert.Nil(t, err) {
+				unlock()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			unlock, err := LockResources(ctx, "fip-bbbbbbbb", "fip-aaaaaaaa")
+			if assert.Nil(t, err) {
+				unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLockResources_IgnoresEmptyAndDuplicateIDs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	unlock, err := LockResources(ctx, "fip-cccccccc", "", "fip-cccccccc")
+	assert.Nil(t, err)
+	unlock()
+}
+
+func TestLockResources_ReleasesAcquiredLocksOnError(t *testing.T) {
+	unlockHeld, err := LockResource(context.Background(), "fip-eeeeeeee")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = LockResources(ctx, "fip-dddddddd", "fip-eeeeeeee")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	unlockHeld()
+
+	// fip-dddddddd must have been released when the second lock timed out
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	unlock, err := LockResources(ctx, "fip-dddddddd", "fip-eeeeeeee")
+	assert.Nil(t, err)
+	unlock()
+}
+
+func TestLockResources_Contended_ReportsHolderAndKeys(t *testing.T) {
+	unlockHeld, err := LockResources(context.Background(), "fip-gggggggg", "i-gggggggg")
+	assert.Nil(t, err)
+	defer unlockHeld()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = LockResource(ctx, "i-gggggggg")
+	assert.ErrorContains(t, err, "held by [TestLockResources_Contended_ReportsHolderAndKeys [fip-gggggggg, i-gggggggg]]")
+}
+
+// resetKVMutex restores the package to its initial state, returning a func undoing the reset
+func resetKVMutex() func() {
+	kvMutexLock.Lock()
+	defer kvMutexLock.Unlock()
+
+	m, used, dir := kvMutex, kvMutexUsed, fileLockDir
+	kvMutex, kvMutexUsed, fileLockDir = kvmutex.NewKVMutex(), false, ""
+
+	return func() {
+		kvMutexLock.Lock()
+		defer kvMutexLock.Unlock()
+		kvMutex, kvMutexUsed, fileLockDir = m, used, dir
+	}
+}
+
+func TestUseFileLocks_BeforeLockTaken_UsesFileLocks(t *testing.T) {
+	defer resetKVMutex()()
+	dir := t.TempDir()
+
+	assert.Nil(t, UseFileLocks(dir))
+
+	unlock, err := LockResource(context.Background(), "fip-99999999")
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(dir, "fip-99999999.lock"))
+	unlock()
+}
+
+func TestUseFileLocks_AfterLockTaken_ReturnsError(t *testing.T) {
+	defer resetKVMutex()()
+
+	unlock, err := LockResource(context.Background(), "fip-99999999")
+	assert.Nil(t, err)
+	defer unlock()
+
+	err = UseFileLocks(t.TempDir())
+	assert.EqualError(t, err, "file locks must be configured before any resources are locked")
+}
+
+func TestUseFileLocks_SameDirAfterLockTaken_Succeeds(t *testing.T) {
+	defer resetKVMutex()()
+	dir := t.TempDir()
+
+	assert.Nil(t, UseFileLocks(dir))
+	unlock, err := LockResource(context.Background(), "fip-99999999")
+	assert.Nil(t, err)
+	unlock()
+
+	assert.Nil(t, UseFileLocks(dir))
+}
+
+func TestUseFileLocks_ConcurrentWithLockResource(t *testing.T) {
+	defer resetKVMutex()()
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			UseFileLocks(dir)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			unlock, err := LockResource(ctx, "fip-ffffffff")
+			if assert.Nil(t, err) {
+				unlock()
+			}
+		}
+	}()
+	wg.Wait()
+}
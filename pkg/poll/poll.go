@@ -0,0 +1,151 @@
+package poll
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RefreshFunc retrieves the current state of the object being waited on. It has the same
+// signature as resource.StateRefreshFunc
+type RefreshFunc func() (result interface{}, state string, err error)
+
+// Config holds the polling behaviour of a Poller
+type Config struct {
+	// Delay is the wait before the first poll
+	Delay time.Duration
+	// MinInterval is the wait after the first poll, doubled for each subsequent poll
+	MinInterval time.Duration
+	// MaxInterval caps the exponential backoff between polls
+	MaxInterval time.Duration
+	// Jitter randomises each interval by up to the given fraction either way, e.g. 0.2 for +/-20%
+	Jitter float64
+	// NotFoundChecks is the number of consecutive polls which must report an object as deleted
+	// before a delete is considered complete
+	NotFoundChecks int
+}
+
+// DefaultConfig matches the polling behaviour prior to it being configurable
+var DefaultConfig = Config{
+	Delay:          5 * time.Second,
+	MinInterval:    3 * time.Second,
+	MaxInterval:    10 * time.Second,
+	Jitter:         0.2,
+	NotFoundChecks: 1,
+}
+
+// Wait describes a single wait for an object to reach a state
+type Wait struct {
+	Target  string
+	Refresh RefreshFunc
+	Timeout time.Duration
+	// Deleting indicates Target is reported once the object no longer exists, in which case it
+	// must be reported for NotFoundChecks consecutive polls
+	Deleting bool
+}
+
+// TimeoutError is returned when the target state isn't reached within the timeout
+type TimeoutError struct {
+	LastState string
+	Target    string
+	Timeout   time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout while waiting for state to become '%s' (last state: '%s', timeout: %s)", e.Target, e.LastState, e.Timeout)
+}
+
+// Poller polls for an object to reach a state using jittered exponential backoff
+type Poller struct {
+	config Config
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewPoller returns a Poller with given configuration
+func NewPoller(config Config) *Poller {
+	if config.NotFoundChecks < 1 {
+		config.NotFoundChecks = 1
+	}
+	if config.MaxInterval < config.MinInterval {
+		config.MaxInterval = config.MinInterval
+	}
+
+	return &Poller{
+		config: config,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Config returns the configuration of the poller
+func (p *Poller) Config() Config {
+	return p.config
+}
+
+// WaitForState polls w.Refresh until it reports w.Target, returning the last result. An error
+// is returned if w.Refresh fails, the timeout elapses or ctx is done
+func (p *Poller) WaitForState(ctx context.Context, w Wait) (interface{}, error) {
+	var timeout <-chan time.Time
+	if w.Timeout > 0 {
+		timer := time.NewTimer(w.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	lastState := ""
+	targetOccurrences := 0
+	wait := p.config.Delay
+	for attempt := 0; ; attempt++ {
+		delay := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			delay.Stop()
+			return nil, ctx.Err()
+		case <-timeout:
+			delay.Stop()
+			return nil, &TimeoutError{LastState: lastState, Target: w.Target, Timeout: w.Timeout}
+		case <-delay.C:
+		}
+
+		result, state, err := w.Refresh()
+		if err != nil {
+			return result, err
+		}
+		lastState = state
+
+		if state == w.Target {
+			targetOccurrences++
+			if !w.Deleting || targetOccurrences >= p.config.NotFoundChecks {
+				return result, nil
+			}
+		} else {
+			targetOccurrences = 0
+		}
+
+		wait = p.interval(attempt)
+	}
+}
+
+// interval returns the jittered wait following given (zero-indexed) attempt
+func (p *Poller) interval(attempt int) time.Duration {
+	wait := p.config.MinInterval
+	for i := 0; i < attempt && wait < p.config.MaxInterval; i++ {
+		wait *= 2
+	}
+
+	if wait > p.config.MaxInterval {
+		wait = p.config.MaxInterval
+	}
+
+	if p.config.Jitter > 0 && wait > 0 {
+		p.mu.Lock()
+		factor := 1 + p.config.Jitter*(2*p.rand.Float64()-1)
+		p.mu.Unlock()
+		wait = time.Duration(float64(wait) * factor)
+	}
+
+	return wait
+}
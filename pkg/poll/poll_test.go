@@ -0,0 +1,112 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRefreshFunc(states ...string) (RefreshFunc, *int) {
+	calls := 0
+	return func() (interface{}, string, error) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		return state, state, nil
+	}, &calls
+}
+
+func TestPoller_WaitForState(t *testing.T) {
+	t.Run("ReturnsOnTarget", func(t *testing.T) {
+		refresh, calls := testRefreshFunc("pending", "pending", "complete")
+		p := NewPoller(Config{})
+
+		result, err := p.WaitForState(context.Background(), Wait{Target: "complete", Refresh: refresh, Timeout: time.Second})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "complete", result)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("RefreshError_ReturnsError", func(t *testing.T) {
+		p := NewPoller(Config{})
+
+		_, err := p.WaitForState(context.Background(), Wait{
+			Target: "complete",
+			Refresh: func() (interface{}, string, error) {
+				return nil, "", errors.New("test error")
+			},
+			Timeout: time.Second,
+		})
+
+		assert.EqualError(t, err, "test error")
+	})
+
+	t.Run("Timeout_ReturnsTimeoutError", func(t *testing.T) {
+		refresh, _ := testRefreshFunc("pending")
+		p := NewPoller(Config{MinInterval: time.Millisecond})
+
+		_, err := p.WaitForState(context.Background(), Wait{Target: "complete", Refresh: refresh, Timeout: 20 * time.Millisecond})
+
+		assert.IsType(t, &TimeoutError{}, err)
+		assert.Equal(t, "pending", err.(*TimeoutError).LastState)
+	})
+
+	t.Run("ContextDone_ReturnsContextError", func(t *testing.T) {
+		refresh, _ := testRefreshFunc("pending")
+		p := NewPoller(Config{MinInterval: time.Millisecond})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := p.WaitForState(ctx, Wait{Target: "complete", Refresh: refresh})
+
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("Deleting_RequiresConsecutiveNotFoundChecks", func(t *testing.T) {
+		refresh, calls := testRefreshFunc("Deleted", "complete", "Deleted", "Deleted", "Deleted")
+		p := NewPoller(Config{NotFoundChecks: 3})
+
+		_, err := p.WaitForState(context.Background(), Wait{Target: "Deleted", Refresh: refresh, Timeout: time.Second, Deleting: true})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 5, *calls)
+	})
+
+	t.Run("NotDeleting_IgnoresNotFoundChecks", func(t *testing.T) {
+		refresh, calls := testRefreshFunc("complete")
+		p := NewPoller(Config{NotFoundChecks: 3})
+
+		_, err := p.WaitForState(context.Background(), Wait{Target: "complete", Refresh: refresh, Timeout: time.Second})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestPoller_interval(t *testing.T) {
+	t.Run("ExponentialBackoffCappedAtMaxInterval", func(t *testing.T) {
+		p := NewPoller(Config{MinInterval: time.Second, MaxInterval: 5 * time.Second})
+
+		assert.Equal(t, time.Second, p.interval(0))
+		assert.Equal(t, 2*time.Second, p.interval(1))
+		assert.Equal(t, 4*time.Second, p.interval(2))
+		assert.Equal(t, 5*time.Second, p.interval(3))
+		assert.Equal(t, 5*time.Second, p.interval(10))
+	})
+
+	t.Run("JitterWithinBounds", func(t *testing.T) {
+		p := NewPoller(Config{MinInterval: 10 * time.Second, MaxInterval: 10 * time.Second, Jitter: 0.2})
+
+		for i := 0; i < 100; i++ {
+			wait := p.interval(0)
+			assert.GreaterOrEqual(t, int64(wait), int64(8*time.Second))
+			assert.LessOrEqual(t, int64(wait), int64(12*time.Second))
+		}
+	})
+}
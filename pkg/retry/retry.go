@@ -0,0 +1,204 @@
+package retry
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+)
+
+// Config holds the retry behaviour for a Connection
+type Config struct {
+	// MaxRetries is the maximum number of times a request is retried after the initial attempt
+	MaxRetries int
+	// WaitMin is the wait before the first retry, doubled for each subsequent retry
+	WaitMin time.Duration
+	// WaitMax caps the exponential backoff between retries
+	WaitMax time.Duration
+}
+
+// Connection wraps a connection.Connection, retrying requests which fail with a transient
+// error. Idempotent requests are retried on transport errors and 5xx responses, whereas
+// other requests are only retried where the API indicates the request was never processed
+// (429 / 503 responses, or a failure to connect)
+type Connection struct {
+	conn   connection.Connection
+	config Config
+	sleep  func(time.Duration)
+}
+
+// NewConnection returns a Connection wrapping conn with given retry configuration
+func NewConnection(conn connection.Connection, config Config) *Connection {
+	return &Connection{
+		conn:   conn,
+		config: config,
+		sleep:  time.Sleep,
+	}
+}
+
+// Get invokes a GET request, returning an APIResponse
+func (c *Connection) Get(resource string, parameters connection.APIRequestParameters) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:     http.MethodGet,
+		Resource:   resource,
+		Parameters: parameters,
+	})
+}
+
+// Post invokes a POST request, returning an APIResponse
+func (c *Connection) Post(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodPost,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Put invokes a PUT request, returning an APIResponse
+func (c *Connection) Put(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodPut,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Patch invokes a PATCH request, returning an APIResponse
+func (c *Connection) Patch(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodPatch,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Delete invokes a DELETE request, returning an APIResponse
+func (c *Connection) Delete(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.Invoke(connection.APIRequest{
+		Method:   http.MethodDelete,
+		Resource: resource,
+		Body:     body,
+	})
+}
+
+// Invoke invokes a request, retrying on transient failures, returning an APIResponse
+func (c *Connection) Invoke(request connection.APIRequest) (*connection.APIResponse, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.conn.Invoke(request)
+
+		retryable, retryAfter := c.shouldRetry(request, resp, err)
+		if !retryable || attempt >= c.config.MaxRetries {
+			return resp, err
+		}
+
+		// Retry-After takes precedence over backoff, though is capped so that a large value
+		// can't stall the caller
+		wait := c.backoff(attempt)
+		if retryAfter > wait {
+			wait = min(retryAfter, c.config.WaitMax)
+		}
+
+		if err != nil {
+			log.Printf("[WARN] %s %s failed (attempt %d/%d), retrying in %s: %s", request.Method, request.Resource, attempt+1, c.config.MaxRetries+1, wait, err)
+		} else {
+			log.Printf("[WARN] %s %s returned status %d (attempt %d/%d), retrying in %s", request.Method, request.Resource, resp.StatusCode, attempt+1, c.config.MaxRetries+1, wait)
+			drainResponse(resp)
+		}
+
+		c.sleep(wait)
+	}
+}
+
+// shouldRetry returns whether request should be retried given its outcome, along with any
+// wait requested by the API via the Retry-After header
+func (c *Connection) shouldRetry(request connection.APIRequest, resp *connection.APIResponse, err error) (bool, time.Duration) {
+	// A streamed body cannot be replayed
+	if _, ok := request.Body.(io.Reader); ok {
+		return false, 0
+	}
+
+	if err != nil {
+		if isConnectError(err) {
+			return true, 0
+		}
+		return isIdempotent(request.Method), 0
+	}
+
+	if resp == nil || resp.Response == nil {
+		return false, 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true, parseRetryAfter(resp.Header.Get("Retry-After"))
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(request.Method), parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	return false, 0
+}
+
+// backoff returns the exponential wait for given (zero-indexed) attempt
+func (c *Connection) backoff(attempt int) time.Duration {
+	wait := c.config.WaitMin
+	for i := 0; i < attempt && wait < c.config.WaitMax; i++ {
+		wait *= 2
+	}
+
+	if wait > c.config.WaitMax {
+		return c.config.WaitMax
+	}
+
+	return wait
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+// isConnectError returns true if err indicates the request never reached the API
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// parseRetryAfter parses a Retry-After header value in either delay-seconds or HTTP-date form
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+func drainResponse(resp *connection.APIResponse) {
+	if resp.Body != nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,179 @@
+package retry
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/stretchr/testify/assert"
+)
+
+type testResult struct {
+	statusCode int
+	header     http.Header
+	err        error
+}
+
+type TestConnection struct {
+	connection.Connection
+	results []testResult
+	calls   int
+}
+
+func (c *TestConnection) Invoke(request connection.APIRequest) (*connection.APIResponse, error) {
+	result := c.results[c.calls]
+	c.calls++
+
+	if result.err != nil {
+		return &connection.APIResponse{}, result.err
+	}
+
+	return &connection.APIResponse{
+		Response: &http.Response{
+			StatusCode: result.statusCode,
+			Header:     result.header,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		},
+	}, nil
+}
+
+func newTestConnection(results ...testResult) (*TestConnection, *Connection, *[]time.Duration) {
+	inner := &TestConnection{results: results}
+	waits := &[]time.Duration{}
+	conn := NewConnection(inner, Config{MaxRetries: 3, WaitMin: time.Second, WaitMax: 3 * time.Second})
+	conn.sleep = func(d time.Duration) {
+		*waits = append(*waits, d)
+	}
+	return inner, conn, waits
+}
+
+func TestConnection_Get_RetriesServerErrors(t *testing.T) {
+	inner, conn, waits := newTestConnection(
+		testResult{statusCode: 502},
+		testResult{statusCode: 500},
+		testResult{statusCode: 504},
+		testResult{statusCode: 200},
+	)
+
+	resp, err := conn.Get("/ecloud/v2/instances/i-abcdef12", connection.APIRequestParameters{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 4, inner.calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, *waits)
+}
+
+func TestConnection_Get_ReturnsLastResponseWhenRetriesExhausted(t *testing.T) {
+	inner, conn, _ := newTestConnection(
+		testResult{statusCode: 502},
+		testResult{statusCode: 502},
+		testResult{statusCode: 502},
+		testResult{statusCode: 502},
+	)
+
+	resp, err := conn.Get("/ecloud/v2/tasks/task-abcdef12", connection.APIRequestParameters{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 502, resp.StatusCode)
+	assert.Equal(t, 4, inner.calls)
+}
+
+func TestConnection_Get_HonoursRetryAfter(t *testing.T) {
+	_, conn, waits := newTestConnection(
+		testResult{statusCode: 429, header: http.Header{"Retry-After": []string{"2"}}},
+		testResult{statusCode: 200},
+	)
+
+	_, err := conn.Get("/ecloud/v2/vpcs", connection.APIRequestParameters{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *waits)
+}
+
+func TestConnection_Get_CapsRetryAfterAtWaitMax(t *testing.T) {
+	_, conn, waits := newTestConnection(
+		testResult{statusCode: 429, header: http.Header{"Retry-After": []string{"3600"}}},
+		testResult{statusCode: 200},
+	)
+
+	_, err := conn.Get("/ecloud/v2/vpcs", connection.APIRequestParameters{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Duration{3 * time.Second}, *waits)
+}
+
+func TestConnection_Get_RetriesTransportErrors(t *testing.T) {
+	inner, conn, _ := newTestConnection(
+		testResult{err: errors.New("api request failed: connection reset by peer")},
+		testResult{statusCode: 200},
+	)
+
+	_, err := conn.Get("/ecloud/v2/vpcs", connection.APIRequestParameters{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestConnection_Post_DoesNotRetryAmbiguousFailures(t *testing.T) {
+	t.Run("ServerError", func(t *testing.T) {
+		inner, conn, _ := newTestConnection(testResult{statusCode: 502})
+
+		resp, _ := conn.Post("/ecloud/v2/instances", nil)
+
+		assert.Equal(t, 502, resp.StatusCode)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("TransportError", func(t *testing.T) {
+		inner, conn, _ := newTestConnection(testResult{err: errors.New("api request failed: EOF")})
+
+		_, err := conn.Post("/ecloud/v2/instances", nil)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, inner.calls)
+	})
+}
+
+func TestConnection_Post_RetriesUnprocessedRequests(t *testing.T) {
+	t.Run("TooManyRequests", func(t *testing.T) {
+		inner, conn, _ := newTestConnection(testResult{statusCode: 429}, testResult{statusCode: 202})
+
+		resp, _ := conn.Post("/ecloud/v2/instances", nil)
+
+		assert.Equal(t, 202, resp.StatusCode)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("ServiceUnavailable", func(t *testing.T) {
+		inner, conn, _ := newTestConnection(testResult{statusCode: 503}, testResult{statusCode: 202})
+
+		resp, _ := conn.Post("/ecloud/v2/instances", nil)
+
+		assert.Equal(t, 202, resp.StatusCode)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("DialError", func(t *testing.T) {
+		dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+		inner, conn, _ := newTestConnection(testResult{err: dialErr}, testResult{statusCode: 202})
+
+		resp, _ := conn.Post("/ecloud/v2/instances", nil)
+
+		assert.Equal(t, 202, resp.StatusCode)
+		assert.Equal(t, 2, inner.calls)
+	})
+}
+
+func TestConnection_Get_DoesNotRetryClientErrors(t *testing.T) {
+	inner, conn, _ := newTestConnection(testResult{statusCode: 404})
+
+	resp, _ := conn.Get("/ecloud/v2/vpcs/vpc-abcdef12", connection.APIRequestParameters{})
+
+	assert.Equal(t, 404, resp.StatusCode)
+	assert.Equal(t, 1, inner.calls)
+}